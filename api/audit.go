@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AuditEntry is a single audited resolver call. UnverifiedCaller is read from the token claims without verifying
+// the signature of the token.
+type AuditEntry struct {
+	Time             time.Time              `json:"time"`
+	Resolver         string                 `json:"resolver"`
+	UnverifiedCaller string                 `json:"unverifiedCaller,omitempty"`
+	Args             map[string]interface{} `json:"args,omitempty"`
+	TypeName         string                 `json:"typeName,omitempty"`
+	Operation        string                 `json:"operation,omitempty"`
+	CommitTs         uint64                 `json:"commitTs,omitempty"`
+	RootUIDs         []string               `json:"rootUIDs,omitempty"`
+	Status           int                    `json:"status"`
+	Error            string                 `json:"error,omitempty"`
+	DurationMs       int64                  `json:"durationMs"`
+}
+
+type AuditSink interface {
+	Write(entry *AuditEntry) error
+}
+
+// FileAuditSink appends every entry as a single json line to a file.
+type FileAuditSink struct {
+	Filename string
+	mu       sync.Mutex
+}
+
+func NewFileAuditSink(filename string) *FileAuditSink {
+	return &FileAuditSink{Filename: filename}
+}
+
+func (s *FileAuditSink) Write(entry *AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "Could not marshal audit entry")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dir := filepath.Dir(s.Filename); dir != "" {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return errors.Wrap(err, "Could not create audit directory")
+		}
+	}
+	f, err := os.OpenFile(s.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "Could not open audit file")
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+type AuditOptions struct {
+	// Sink defaults to a FileAuditSink writing to audit.jsonl
+	Sink AuditSink
+	// Resolvers are the resolver prefixes to audit, defaults to Mutation. and $webhook
+	Resolvers []string
+	// IdentityClaim is the jwt claim identifying the caller, defaults to sub
+	IdentityClaim string
+	// Redact lists additional paths to redact, see Redactor
+	Redact []string
+}
+
+type auditedExecuter struct {
+	executer ExecuterInterface
+	options  AuditOptions
+	redactor *Redactor
+}
+
+// EnableAudit writes an audit entry for every audited resolver call, including background webhooks.
+func (l *Lambda) EnableAudit(options AuditOptions) {
+	if options.Sink == nil {
+		options.Sink = NewFileAuditSink("audit.jsonl")
+	}
+	if len(options.Resolvers) == 0 {
+		options.Resolvers = []string{"Mutation.", "$webhook"}
+	}
+	if options.IdentityClaim == "" {
+		options.IdentityClaim = "sub"
+	}
+
+	if l.Redactor == nil {
+		l.Redactor = NewRedactor()
+	}
+	l.Redactor.Add(options.Redact...)
+	l.Executor = &auditedExecuter{executer: l.Executor, options: options, redactor: l.Redactor}
+}
+
+func (e *auditedExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	if !e.audited(request.Resolver) {
+		return e.executer.Resolve(ctx, request)
+	}
+
+	start := time.Now()
+	res, err := e.executer.Resolve(ctx, request)
+
+	entry := &AuditEntry{
+		Time:             start.UTC(),
+		Resolver:         request.Resolver,
+		UnverifiedCaller: caller(request, e.options.IdentityClaim),
+		Status:           http.StatusOK,
+		DurationMs:       time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Status = int(err.Status)
+		entry.Error = err.Error()
+	}
+	if request.Event != nil {
+		entry.TypeName = request.Event.TypeName
+		entry.Operation = request.Event.Operation
+		entry.CommitTs = request.Event.CommitTs
+		entry.RootUIDs = rootUIDs(request.Event)
+	} else {
+		entry.Args = e.redactor.Args(request.Resolver, request.Args)
+	}
+
+	if writeErr := e.options.Sink.Write(entry); writeErr != nil {
+		fmt.Println(writeErr.Error())
+	}
+	return res, err
+}
+
+func (e *auditedExecuter) audited(resolver string) bool {
+	for _, prefix := range e.options.Resolvers {
+		if strings.HasPrefix(resolver, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// caller reads the identity claim from the access token or the auth header. The signature is not verified, so the
+// caller is only as trustworthy as the connection between Dgraph and the lambda.
+func caller(request *Request, claim string) string {
+	claims := tokenClaims(request.AccessToken)
+	if claims == nil {
+		claims = tokenClaims(strings.TrimPrefix(request.AuthHeader.Value, "Bearer "))
+	}
+	if claims == nil {
+		return ""
+	}
+	if id, ok := claims[claim]; ok {
+		return fmt.Sprint(id)
+	}
+	// Dgraph claims are usually nested in a namespace like https://dgraph.io/jwt/claims
+	for _, value := range claims {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if id, ok := nested[claim]; ok {
+				return fmt.Sprint(id)
+			}
+		}
+	}
+	return ""
+}
+
+func tokenClaims(token string) map[string]interface{} {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(b, &claims); err != nil {
+		return nil
+	}
+	return claims
+}
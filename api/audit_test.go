@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryAuditSink struct {
+	entries []*AuditEntry
+}
+
+func (s *memoryAuditSink) Write(entry *AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+type redactingExecuter struct{}
+
+func (e redactingExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	if request.Resolver == "Mutation.fail" {
+		return nil, &LambdaError{Underlying: errors.New("failed"), Status: http.StatusBadRequest}
+	}
+	return []byte(`"0x1"`), nil
+}
+
+func (e redactingExecuter) Redactions() []string {
+	return []string{"Mutation.createUser.input.password"}
+}
+
+func Test_Audit(t *testing.T) {
+	sink := &memoryAuditSink{}
+	lambda := New(redactingExecuter{})
+	lambda.EnableAudit(AuditOptions{Sink: sink, Redact: []string{"Mutation.createUser.token"}})
+
+	claims, _ := json.Marshal(map[string]interface{}{"https://dgraph.io/jwt/claims": map[string]interface{}{"sub": "user-1"}})
+	token := "header." + base64.RawURLEncoding.EncodeToString(claims) + ".signature"
+
+	_, err := lambda.Executor.Resolve(context.Background(), &Request{
+		Resolver:    "Mutation.createUser",
+		AccessToken: token,
+		Args: map[string]json.RawMessage{
+			"input": json.RawMessage(`[{"name": "Alice", "password": "secret"}]`),
+			"token": json.RawMessage(`"abc"`),
+		},
+	})
+	assert.Nil(t, err)
+	_, err = lambda.Executor.Resolve(context.Background(), &Request{Resolver: "Query.users", Args: map[string]json.RawMessage{}})
+	assert.Nil(t, err)
+	_, err = lambda.Executor.Resolve(context.Background(), &Request{Resolver: "Mutation.fail", Args: map[string]json.RawMessage{}})
+	assert.NotNil(t, err)
+
+	assert.Len(t, sink.entries, 2)
+	entry := sink.entries[0]
+	assert.Equal(t, "user-1", entry.UnverifiedCaller)
+	assert.Equal(t, http.StatusOK, entry.Status)
+	assert.Equal(t, Redacted, entry.Args["token"])
+	input := entry.Args["input"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Alice", input["name"])
+	assert.Equal(t, Redacted, input["password"])
+
+	assert.Equal(t, http.StatusBadRequest, sink.entries[1].Status)
+	assert.Equal(t, "failed", sink.entries[1].Error)
+}
+
+func Test_Audit_Async_Webhooks(t *testing.T) {
+	sink := &memoryAuditSink{}
+	lambda := New(redactingExecuter{})
+	// Audit applies to the queue even if it is enabled afterwards
+	queue := lambda.EnableAsyncWebhooks(WebhookOptions{DeadLetter: newTestDeadLetterStore(t)})
+	lambda.EnableAudit(AuditOptions{Sink: sink})
+
+	assert.NoError(t, queue.Enqueue(&Request{Resolver: "$webhook", Event: &Event{TypeName: "User", Operation: "add", CommitTs: 1}}))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Len(t, sink.entries, 1)
+	assert.Equal(t, "User", sink.entries[0].TypeName)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type DeadLetter struct {
+	ID       string    `json:"id"`
+	Request  *Request  `json:"request"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+type DeadLetterStore interface {
+	Put(letter *DeadLetter) error
+	List() ([]*DeadLetter, error)
+	Delete(id string) error
+}
+
+// FileDeadLetterStore writes every dead letter as a separate json file into Dir. Dead letters can contain auth headers
+// and secrets, so only the owner can read them.
+type FileDeadLetterStore struct {
+	Dir string
+	mu  sync.Mutex
+	seq uint64
+}
+
+func NewFileDeadLetterStore(dir string) *FileDeadLetterStore {
+	return &FileDeadLetterStore{Dir: dir}
+}
+
+func (s *FileDeadLetterStore) Put(letter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return errors.Wrap(err, "Could not create dead letter directory")
+	}
+
+	if letter.ID == "" {
+		s.seq++
+		letter.ID = fmt.Sprintf("%d-%d", letter.FailedAt.UnixNano(), s.seq)
+		if letter.Request != nil && letter.Request.Event != nil {
+			letter.ID = fmt.Sprintf("%s-%s-%s", letter.ID, letter.Request.Event.TypeName, letter.Request.Event.Operation)
+		}
+	}
+
+	b, err := json.MarshalIndent(letter, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Could not marshal dead letter")
+	}
+	return ioutil.WriteFile(s.filename(letter.ID), b, 0600)
+}
+
+func (s *FileDeadLetterStore) List() ([]*DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := ioutil.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read dead letter directory")
+	}
+
+	var letters []*DeadLetter
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(s.Dir, f.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not read dead letter %s", f.Name())
+		}
+		var letter *DeadLetter
+		if err := json.Unmarshal(b, &letter); err != nil {
+			return nil, errors.Wrapf(err, "Could not parse dead letter %s", f.Name())
+		}
+		letters = append(letters, letter)
+	}
+
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+	return letters, nil
+}
+
+func (s *FileDeadLetterStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.filename(id)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "Could not delete dead letter %s", id)
+	}
+	return nil
+}
+
+func (s *FileDeadLetterStore) filename(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
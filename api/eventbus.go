@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type EventHandler func(ctx context.Context, event *Event) error
+
+type subscription struct {
+	id         uint64
+	typeName   string
+	operations []string
+	handler    EventHandler
+}
+
+// EventBus passes webhook events to all subscribers registered for their type and operation.
+type EventBus struct {
+	mu            sync.RWMutex
+	nextID        uint64
+	subscriptions []*subscription
+}
+
+// DefaultEventBus is used by generated executers unless another bus is passed.
+var DefaultEventBus = NewEventBus()
+
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe registers a handler for a type and operations. An empty type name or no operations match all events.
+// The returned function removes the subscription.
+func (b *EventBus) Subscribe(typeName string, operations []string, handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, &subscription{id: id, typeName: typeName, operations: operations, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for i, s := range b.subscriptions {
+			if s.id == id {
+				b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish runs all matching subscribers in parallel and waits for them. A failing or panicking subscriber does not
+// affect the others, all errors are returned combined.
+func (b *EventBus) Publish(ctx context.Context, event *Event) error {
+	if b == nil || event == nil {
+		return nil
+	}
+
+	b.mu.RLock()
+	var matching []*subscription
+	for _, s := range b.subscriptions {
+		if matchesEvent(s.typeName, s.operations, event) {
+			matching = append(matching, s)
+		}
+	}
+	b.mu.RUnlock()
+
+	errs := make([]error, len(matching))
+	var wg sync.WaitGroup
+	for i, s := range matching {
+		wg.Add(1)
+		go func(i int, s *subscription) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = errors.Errorf("Subscriber panicked: %v", r)
+				}
+			}()
+			errs[i] = s.handler(ctx, event)
+		}(i, s)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func matchesEvent(typeName string, operations []string, event *Event) bool {
+	if typeName != "" && typeName != event.TypeName {
+		return false
+	}
+	if len(operations) == 0 {
+		return true
+	}
+	for _, operation := range operations {
+		if operation == event.Operation {
+			return true
+		}
+	}
+	return false
+}
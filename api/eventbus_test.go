@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EventBus_Publish(t *testing.T) {
+	bus := NewEventBus()
+
+	var indexed, notified, all int32
+	bus.Subscribe("User", []string{"add"}, func(ctx context.Context, event *Event) error {
+		atomic.AddInt32(&indexed, 1)
+		return errors.New("index unavailable")
+	})
+	bus.Subscribe("User", []string{"add", "delete"}, func(ctx context.Context, event *Event) error {
+		atomic.AddInt32(&notified, 1)
+		return nil
+	})
+	unsubscribe := bus.Subscribe("", nil, func(ctx context.Context, event *Event) error {
+		atomic.AddInt32(&all, 1)
+		panic("boom")
+	})
+
+	err := bus.Publish(context.Background(), &Event{TypeName: "User", Operation: "add"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "index unavailable")
+	assert.Contains(t, err.Error(), "boom")
+
+	unsubscribe()
+	assert.NoError(t, bus.Publish(context.Background(), &Event{TypeName: "User", Operation: "delete"}))
+	assert.NoError(t, bus.Publish(context.Background(), &Event{TypeName: "Hotel", Operation: "add"}))
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&indexed))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&notified))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&all))
+}
+
+func Test_EventBus_Failing_Subscriber(t *testing.T) {
+	bus := NewEventBus()
+
+	var received int32
+	bus.Subscribe("User", nil, func(ctx context.Context, event *Event) error {
+		return errors.New("failed")
+	})
+	bus.Subscribe("User", nil, func(ctx context.Context, event *Event) error {
+		atomic.AddInt32(&received, 1)
+		return nil
+	})
+
+	// A failing subscriber does not keep the event from the others
+	assert.EqualError(t, bus.Publish(context.Background(), &Event{TypeName: "User", Operation: "update"}), "failed")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&received))
+}
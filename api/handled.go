@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// HandledStore remembers which commitTs were handled per type, operation and root UID.
+type HandledStore interface {
+	Handled(key string, commitTs uint64) (bool, error)
+	MarkHandled(key string, commitTs uint64) error
+}
+
+// FileHandledStore keeps the most recent handled events in memory and appends every new one as a line to a file.
+// The file is compacted to the events in memory once it holds twice as many lines.
+type FileHandledStore struct {
+	Filename string
+	mu       sync.Mutex
+	size     int
+	lines    int
+	handled  *dedupe
+}
+
+// NewFileHandledStore remembers up to size handled events, defaulting to 100000.
+func NewFileHandledStore(filename string, size int) (*FileHandledStore, error) {
+	if size <= 0 {
+		size = 100000
+	}
+	s := &FileHandledStore{Filename: filename, size: size, handled: newDedupe(size)}
+
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read handled events")
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			s.handled.add(line)
+			s.lines++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "Could not read handled events")
+	}
+	return s, nil
+}
+
+func (s *FileHandledStore) Handled(key string, commitTs uint64) (bool, error) {
+	return s.handled.contains(handledEntry(key, commitTs)), nil
+}
+
+func (s *FileHandledStore) MarkHandled(key string, commitTs uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := handledEntry(key, commitTs)
+	if !s.handled.add(entry) {
+		return nil
+	}
+
+	if dir := filepath.Dir(s.Filename); dir != "" {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return errors.Wrap(err, "Could not create handled events directory")
+		}
+	}
+	f, err := os.OpenFile(s.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "Could not open handled events")
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(entry + "\n"); err != nil {
+		return errors.Wrap(err, "Could not write handled event")
+	}
+	s.lines++
+
+	if s.lines >= 2*s.size {
+		return s.compact()
+	}
+	return nil
+}
+
+func (s *FileHandledStore) compact() error {
+	entries := s.handled.list()
+
+	// Write to a temporary file first so a crash never leaves a truncated file behind
+	tmp := s.Filename + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strings.Join(entries, "\n")+"\n"), 0644); err != nil {
+		return errors.Wrap(err, "Could not compact handled events")
+	}
+	if err := os.Rename(tmp, s.Filename); err != nil {
+		return errors.Wrap(err, "Could not compact handled events")
+	}
+	s.lines = len(entries)
+	return nil
+}
+
+func handledEntry(key string, commitTs uint64) string {
+	return fmt.Sprintf("%s@%d", key, commitTs)
+}
+
+// dedupe remembers the most recent keys up to a fixed size.
+type dedupe struct {
+	mu    sync.Mutex
+	size  int
+	keys  map[string]struct{}
+	order []string
+}
+
+func newDedupe(size int) *dedupe {
+	return &dedupe{size: size, keys: make(map[string]struct{})}
+}
+
+func (d *dedupe) contains(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.keys[key]
+	return ok
+}
+
+// add returns false if the key is already known.
+func (d *dedupe) add(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.keys[key]; ok {
+		return false
+	}
+	d.keys[key] = struct{}{}
+	d.order = append(d.order, key)
+
+	if len(d.order) > d.size {
+		delete(d.keys, d.order[0])
+		d.order = d.order[1:]
+	}
+	return true
+}
+
+// list returns the remembered keys from oldest to newest.
+func (d *dedupe) list() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string{}, d.order...)
+}
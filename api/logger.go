@@ -0,0 +1,14 @@
+package api
+
+import (
+	"log"
+	"os"
+)
+
+// Logger receives errors that must not fail a request, e.g. of event sinks and subscribers.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// DefaultLogger writes to stderr.
+var DefaultLogger Logger = log.New(os.Stderr, "", log.LstdFlags)
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ApplyPatch applies the setPatch and removePatch of an update event to a model following the Dgraph patch semantics.
+// Set replaces scalars and references and adds to lists. Remove clears scalars and references if the value matches
+// or is null and removes matching entries from lists. References are matched by their id. The patches can be the
+// raw maps of the event or the generated patch types.
+func ApplyPatch(model interface{}, set interface{}, remove interface{}) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.New("Model must be a pointer to a struct")
+	}
+	v = v.Elem()
+
+	setPatch, err := patchMap(set)
+	if err != nil {
+		return errors.Wrap(err, "Could not read set patch")
+	}
+	removePatch, err := patchMap(remove)
+	if err != nil {
+		return errors.Wrap(err, "Could not read remove patch")
+	}
+
+	fields := jsonFields(v.Type())
+	for name, value := range setPatch {
+		index, ok := fields[name]
+		if !ok {
+			return errors.Errorf("Unknown field %s in set patch", name)
+		}
+		if err := setField(v.Field(index), value); err != nil {
+			return errors.Wrapf(err, "Could not set field %s", name)
+		}
+	}
+	for name, value := range removePatch {
+		index, ok := fields[name]
+		if !ok {
+			return errors.Errorf("Unknown field %s in remove patch", name)
+		}
+		if err := removeField(v.Field(index), value); err != nil {
+			return errors.Wrapf(err, "Could not remove field %s", name)
+		}
+	}
+	return nil
+}
+
+// Diff returns the json names of all fields that differ between two models of the same type.
+func Diff(old interface{}, new interface{}) []string {
+	o := reflect.Indirect(reflect.ValueOf(old))
+	n := reflect.Indirect(reflect.ValueOf(new))
+	if o.Type() != n.Type() || o.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var changed []string
+	for i := 0; i < o.NumField(); i++ {
+		name := jsonName(o.Type().Field(i))
+		if name == "" {
+			continue
+		}
+		if !reflect.DeepEqual(o.Field(i).Interface(), n.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+func patchMap(patch interface{}) (map[string]interface{}, error) {
+	if patch == nil {
+		return nil, nil
+	}
+	if m, ok := patch.(map[string]interface{}); ok {
+		return m, nil
+	}
+	if v := reflect.ValueOf(patch); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
+
+func jsonName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	if tag == "-" {
+		return ""
+	}
+	if tag == "" {
+		return field.Name
+	}
+	return tag
+}
+
+func jsonFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+func decodeValue(value interface{}, t reflect.Type) (reflect.Value, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(b, v.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return v.Elem(), nil
+}
+
+// referenceID returns the id of a referenced node or false if the value is not a reference.
+func referenceID(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	index, ok := jsonFields(v.Type())["id"]
+	if !ok || v.Field(index).Kind() != reflect.String {
+		return "", false
+	}
+	return v.Field(index).String(), true
+}
+
+func sameValue(a reflect.Value, b reflect.Value) bool {
+	aID, aOk := referenceID(a)
+	bID, bOk := referenceID(b)
+	if aOk && bOk && aID != "" {
+		return aID == bID
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func setField(field reflect.Value, value interface{}) error {
+	patch, err := decodeValue(value, field.Type())
+	if err != nil {
+		return err
+	}
+	if field.Kind() != reflect.Slice {
+		field.Set(patch)
+		return nil
+	}
+
+	list := field
+	for i := 0; i < patch.Len(); i++ {
+		found := false
+		for j := 0; j < list.Len(); j++ {
+			if sameValue(list.Index(j), patch.Index(i)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = reflect.Append(list, patch.Index(i))
+		}
+	}
+	field.Set(list)
+	return nil
+}
+
+func removeField(field reflect.Value, value interface{}) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	patch, err := decodeValue(value, field.Type())
+	if err != nil {
+		return err
+	}
+	if field.Kind() != reflect.Slice {
+		if sameValue(field, patch) {
+			field.Set(reflect.Zero(field.Type()))
+		}
+		return nil
+	}
+
+	list := reflect.MakeSlice(field.Type(), 0, field.Len())
+	for i := 0; i < field.Len(); i++ {
+		removed := false
+		for j := 0; j < patch.Len(); j++ {
+			if sameValue(field.Index(i), patch.Index(j)) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			list = reflect.Append(list, field.Index(i))
+		}
+	}
+	field.Set(list)
+	return nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type patchTag struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type patchQuestion struct {
+	Id       string      `json:"id"`
+	Title    string      `json:"title"`
+	Text     string      `json:"text"`
+	Keywords []string    `json:"keywords"`
+	Tags     []*patchTag `json:"tags"`
+	Author   *patchTag   `json:"author"`
+}
+
+type patchQuestionPatch struct {
+	Title string      `json:"title,omitempty"`
+	Tags  []*patchTag `json:"tags,omitempty"`
+}
+
+func Test_ApplyPatch(t *testing.T) {
+	question := &patchQuestion{
+		Id:       "0x1",
+		Title:    "Old",
+		Text:     "Text",
+		Keywords: []string{"a", "b"},
+		Tags:     []*patchTag{{Id: "0x2", Name: "go"}},
+		Author:   &patchTag{Id: "0x5"},
+	}
+	old := *question
+
+	set := map[string]interface{}{
+		"title":    "New",
+		"keywords": []interface{}{"b", "c"},
+		"tags":     []interface{}{map[string]interface{}{"id": "0x2"}, map[string]interface{}{"id": "0x3"}},
+	}
+	remove := map[string]interface{}{
+		"text":     "Other",
+		"keywords": []interface{}{"a"},
+		"author":   map[string]interface{}{"id": "0x5"},
+	}
+	assert.NoError(t, ApplyPatch(question, set, remove))
+
+	assert.Equal(t, "New", question.Title)
+	assert.Equal(t, "Text", question.Text)
+	assert.Equal(t, []string{"b", "c"}, question.Keywords)
+	assert.Len(t, question.Tags, 2)
+	assert.Equal(t, "go", question.Tags[0].Name)
+	assert.Equal(t, "0x3", question.Tags[1].Id)
+	assert.Nil(t, question.Author)
+
+	assert.Equal(t, []string{"title", "keywords", "tags", "author"}, Diff(&old, question))
+
+	assert.NoError(t, ApplyPatch(question, nil, &patchQuestionPatch{Tags: []*patchTag{{Id: "0x2"}}}))
+	assert.Len(t, question.Tags, 1)
+	assert.Equal(t, "0x3", question.Tags[0].Id)
+
+	assert.Error(t, ApplyPatch(question, map[string]interface{}{"unknown": 1}, nil))
+}
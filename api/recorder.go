@@ -0,0 +1,259 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Recording is a redacted request together with the response the executer returned.
+type Recording struct {
+	ID       string          `json:"id"`
+	Time     time.Time       `json:"time"`
+	Request  *Request        `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Status   int             `json:"status"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Recorder writes every resolved request as a single json file into a directory.
+type Recorder struct {
+	Dir      string
+	Redactor *Redactor
+	count    uint64
+}
+
+func NewRecorder(dir string, redactor *Redactor) *Recorder {
+	return &Recorder{Dir: dir, Redactor: redactor}
+}
+
+// EnableRecording records all requests resolved by the executer. Requests are redacted before they are written.
+func (l *Lambda) EnableRecording(dir string) *Recorder {
+	if l.Redactor == nil {
+		l.Redactor = NewRedactor()
+	}
+	l.Recorder = NewRecorder(dir, l.Redactor)
+	return l.Recorder
+}
+
+func (r *Recorder) Record(request *Request, response []byte, lambdaErr *LambdaError) error {
+	now := time.Now().UTC()
+	recording := &Recording{
+		ID:      fmt.Sprintf("%d-%04d-%s", now.UnixNano(), atomic.AddUint64(&r.count, 1)%10000, strings.Replace(request.Resolver, "$", "", 1)),
+		Time:    now,
+		Request: r.Redactor.Request(request),
+		Status:  http.StatusOK,
+	}
+	if len(response) > 0 {
+		if json.Valid(response) {
+			recording.Response = response
+		} else {
+			recording.Response, _ = json.Marshal(string(response))
+		}
+	}
+	if lambdaErr != nil {
+		recording.Status = int(lambdaErr.Status)
+		recording.Error = lambdaErr.Error()
+	}
+
+	b, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Could not marshal recording")
+	}
+	if err := os.MkdirAll(r.Dir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "Could not create recording directory")
+	}
+	return ioutil.WriteFile(filepath.Join(r.Dir, recording.ID+".json"), b, 0644)
+}
+
+// LoadRecordings reads all recordings of a directory ordered by the time they were recorded.
+func LoadRecordings(dir string) ([]*Recording, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read recording directory")
+	}
+
+	var recordings []*Recording
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not read recording %s", file.Name())
+		}
+		var recording *Recording
+		if err := json.Unmarshal(b, &recording); err != nil {
+			return nil, errors.Wrapf(err, "Could not parse recording %s", file.Name())
+		}
+		recordings = append(recordings, recording)
+	}
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].ID < recordings[j].ID
+	})
+	return recordings, nil
+}
+
+type ReplayResult struct {
+	Recording *Recording
+	Response  json.RawMessage
+	Status    int
+	Error     string
+}
+
+// Matches reports whether the replayed response equals the recorded one. Json responses are compared semantically.
+func (r *ReplayResult) Matches() bool {
+	return r.Status == r.Recording.Status && r.Error == r.Recording.Error && sameJSON(r.Response, r.Recording.Response)
+}
+
+func (r *ReplayResult) Diff() string {
+	var diff []string
+	if r.Status != r.Recording.Status {
+		diff = append(diff, fmt.Sprintf("status: recorded %d, got %d", r.Recording.Status, r.Status))
+	}
+	if r.Error != r.Recording.Error {
+		diff = append(diff, fmt.Sprintf("error: recorded %q, got %q", r.Recording.Error, r.Error))
+	}
+	if !sameJSON(r.Response, r.Recording.Response) {
+		diff = append(diff, fmt.Sprintf("response:\n  - %s\n  + %s", string(r.Recording.Response), string(r.Response)))
+	}
+	return strings.Join(diff, "\n")
+}
+
+func Replay(ctx context.Context, executer ExecuterInterface, recording *Recording) *ReplayResult {
+	response, lambdaErr := executer.Resolve(ctx, recording.Request)
+
+	result := &ReplayResult{Recording: recording, Status: http.StatusOK}
+	if len(response) > 0 {
+		if json.Valid(response) {
+			result.Response = response
+		} else {
+			result.Response, _ = json.Marshal(string(response))
+		}
+	}
+	if lambdaErr != nil {
+		result.Status = int(lambdaErr.Status)
+		result.Error = lambdaErr.Error()
+	}
+	return result
+}
+
+// Environment variables the replay command passes the auth of replayed requests in.
+const (
+	ReplayAccessTokenEnv = "LAMBDA_REPLAY_ACCESS_TOKEN"
+	ReplayAuthHeaderEnv  = "LAMBDA_REPLAY_AUTH_HEADER"
+)
+
+type ReplayOptions struct {
+	// ID only replays the recording with this id
+	ID string
+	// AccessToken and AuthHeader replace the redacted values of recordings, so middleware can authorize them
+	AccessToken string
+	AuthHeader  string
+}
+
+func (o ReplayOptions) request(request *Request) *Request {
+	replayed := *request
+	if replayed.AccessToken == Redacted && o.AccessToken != "" {
+		replayed.AccessToken = o.AccessToken
+	}
+	if replayed.AuthHeader.Value == Redacted && o.AuthHeader != "" {
+		replayed.AuthHeader.Value = o.AuthHeader
+	}
+	return &replayed
+}
+
+// ReplayRecordings replays all recordings of a directory, or only the one with the given id, writes the results to w
+// and returns an error if any response differs or the recording with the id does not exist.
+func ReplayRecordings(executer ExecuterInterface, dir string, options ReplayOptions, w io.Writer) error {
+	recordings, err := LoadRecordings(dir)
+	if err != nil {
+		return err
+	}
+
+	replayed, failed := 0, 0
+	for _, recording := range recordings {
+		if options.ID != "" && options.ID != recording.ID {
+			continue
+		}
+		replayed++
+		replay := *recording
+		replay.Request = options.request(recording.Request)
+		result := Replay(context.Background(), executer, &replay)
+		if result.Matches() {
+			fmt.Fprintf(w, "%s\tok\n", recording.ID)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "%s\tdiffers\n%s\n", recording.ID, result.Diff())
+	}
+	if options.ID != "" && replayed == 0 {
+		return errors.Errorf("Could not find recording %s", options.ID)
+	}
+	if failed > 0 {
+		return errors.Errorf("%d responses differ from the recording", failed)
+	}
+	return nil
+}
+
+// RemoteExecuter resolves requests by sending them to a running lambda server.
+type RemoteExecuter struct {
+	URL    string
+	Client *http.Client
+}
+
+func (e *RemoteExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, &LambdaError{Underlying: err, Status: http.StatusBadRequest}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, &LambdaError{Underlying: err, Status: http.StatusBadRequest}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, &LambdaError{Underlying: err, Status: http.StatusBadGateway}
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, &LambdaError{Underlying: err, Status: http.StatusBadGateway}
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, &LambdaError{Underlying: errors.New(string(b)), Status: HttpResponseStatus(res.StatusCode)}
+	}
+	return b, nil
+}
+
+func sameJSON(a json.RawMessage, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var av, bv interface{}
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return bytes.Equal(a, b)
+	}
+	ab, _ := json.Marshal(av)
+	bb, _ := json.Marshal(bv)
+	return bytes.Equal(ab, bb)
+}
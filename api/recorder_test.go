@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type echoExecuter struct {
+	response string
+}
+
+func (e *echoExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	return []byte(e.response), nil
+}
+
+func Test_Record_Replay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	executer := &echoExecuter{response: `{"id": "0x1", "name": "alice"}`}
+	lambda := New(executer)
+	lambda.Redactor.Add("Query.login.password")
+	lambda.EnableRecording(dir)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql-worker", bytes.NewBufferString(`{ "resolver": "Query.login", "args": { "password": "secret" } }`))
+	lambda.Route(httptest.NewRecorder(), req)
+
+	recordings, err := LoadRecordings(dir)
+	assert.NoError(t, err)
+	assert.Len(t, recordings, 1)
+	assert.JSONEq(t, `"[REDACTED]"`, string(recordings[0].Request.Args["password"]))
+	assert.Equal(t, http.StatusOK, recordings[0].Status)
+
+	var out bytes.Buffer
+	executer.response = `{"name": "alice", "id": "0x1"}`
+	assert.NoError(t, ReplayRecordings(executer, dir, ReplayOptions{}, &out))
+
+	executer.response = `{"id": "0x2"}`
+	out.Reset()
+	assert.Error(t, ReplayRecordings(executer, dir, ReplayOptions{}, &out))
+	assert.Contains(t, out.String(), "differs")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request *Request
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Write([]byte(`{"id": "0x1", "name": "alice"}`))
+	}))
+	defer server.Close()
+	out.Reset()
+	assert.NoError(t, ReplayRecordings(&RemoteExecuter{URL: server.URL}, dir, ReplayOptions{ID: recordings[0].ID}, &out))
+	assert.Error(t, ReplayRecordings(&RemoteExecuter{URL: server.URL}, dir, ReplayOptions{ID: "unknown"}, &out))
+}
+
+type authExecuter struct{}
+
+func (e authExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	return []byte(`"` + request.AccessToken + " " + request.AuthHeader.Value + `"`), nil
+}
+
+func Test_Replay_Auth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lambda := New(authExecuter{})
+	lambda.EnableRecording(dir)
+	req := httptest.NewRequest(http.MethodPost, "/graphql-worker", bytes.NewBufferString(`{ "resolver": "Query.me", "args": {}, "X-Dgraph-AccessToken": "a", "authHeader": { "key": "Auth", "value": "b" } }`))
+	lambda.Route(httptest.NewRecorder(), req)
+
+	// The recording holds the response to the real token, the replay only matches with the token passed in
+	var out bytes.Buffer
+	assert.Error(t, ReplayRecordings(authExecuter{}, dir, ReplayOptions{}, &out))
+	assert.NoError(t, ReplayRecordings(authExecuter{}, dir, ReplayOptions{AccessToken: "a", AuthHeader: "b"}, &out))
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const Redacted = "[REDACTED]"
+
+// RedactionProvider is implemented by generated executers and returns the paths of all sensitive arguments and fields.
+type RedactionProvider interface {
+	Redactions() []string
+}
+
+// Redactor replaces sensitive values before requests are logged, audited or recorded. Paths starting with Query or
+// Mutation redact resolver arguments, e.g. Mutation.createUser.input.password. All other paths redact fields of a type
+// in parents and webhook events, e.g. User.password. Access tokens and auth header values are always redacted.
+type Redactor struct {
+	args  map[string][][]string
+	types map[string][][]string
+}
+
+func NewRedactor(paths ...string) *Redactor {
+	r := &Redactor{args: make(map[string][][]string), types: make(map[string][][]string)}
+	r.Add(paths...)
+	return r
+}
+
+func (r *Redactor) Add(paths ...string) {
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		if len(parts) < 2 {
+			continue
+		}
+		if parts[0] == "Query" || parts[0] == "Mutation" {
+			if len(parts) < 3 {
+				continue
+			}
+			resolver := parts[0] + "." + parts[1]
+			r.args[resolver] = append(r.args[resolver], parts[2:])
+		} else {
+			r.types[parts[0]] = append(r.types[parts[0]], parts[1:])
+		}
+	}
+}
+
+// Args decodes the arguments of a resolver and redacts them.
+func (r *Redactor) Args(resolver string, args map[string]json.RawMessage) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{})
+	for name, raw := range args {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+		redacted[name] = value
+	}
+	if r != nil {
+		for _, path := range r.args[resolver] {
+			redactPath(redacted, path)
+		}
+	}
+	return redacted
+}
+
+// Request returns a redacted copy of a request.
+func (r *Redactor) Request(request *Request) *Request {
+	if request == nil {
+		return nil
+	}
+	redacted := *request
+	if redacted.AccessToken != "" {
+		redacted.AccessToken = Redacted
+	}
+	if redacted.AuthHeader.Value != "" {
+		redacted.AuthHeader.Value = Redacted
+	}
+
+	if request.Args != nil {
+		redacted.Args = make(map[string]json.RawMessage)
+		for name, value := range r.Args(request.Resolver, request.Args) {
+			b, _ := json.Marshal(value)
+			redacted.Args[name] = b
+		}
+	}
+
+	if request.Parents != nil && r != nil {
+		if paths := r.types[strings.Split(request.Resolver, ".")[0]]; len(paths) > 0 {
+			var parents interface{}
+			if err := json.Unmarshal(request.Parents, &parents); err == nil {
+				for _, path := range paths {
+					redactPath(parents, path)
+				}
+				redacted.Parents, _ = json.Marshal(parents)
+			}
+		}
+	}
+
+	if request.Event != nil && r != nil {
+		if paths := r.types[request.Event.TypeName]; len(paths) > 0 {
+			var event *Event
+			if err := DecodeEvent(request.Event, &event); err == nil {
+				for _, path := range paths {
+					if event.Add != nil {
+						for _, input := range event.Add.Input {
+							redactPath(input, path)
+						}
+					}
+					if event.Update != nil {
+						redactPath(event.Update.SetPatch, path)
+						redactPath(event.Update.RemovePatch, path)
+					}
+				}
+				redacted.Event = event
+			}
+		}
+	}
+	return &redacted
+}
+
+func redactPath(value interface{}, path []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			return
+		}
+		if len(path) == 1 {
+			v[path[0]] = Redacted
+			return
+		}
+		redactPath(child, path[1:])
+	case []interface{}:
+		for _, item := range v {
+			redactPath(item, path)
+		}
+	}
+}
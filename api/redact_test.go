@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Redactor_Request(t *testing.T) {
+	redactor := NewRedactor("Query.login.password", "User.password")
+
+	request := &Request{
+		Resolver:    "Query.login",
+		AccessToken: "token",
+		AuthHeader:  AuthHeader{Key: "Authorization", Value: "Bearer token"},
+		Args:        map[string]json.RawMessage{"name": json.RawMessage(`"alice"`), "password": json.RawMessage(`"secret"`)},
+	}
+	redacted := redactor.Request(request)
+	assert.Equal(t, Redacted, redacted.AccessToken)
+	assert.Equal(t, Redacted, redacted.AuthHeader.Value)
+	assert.JSONEq(t, `"alice"`, string(redacted.Args["name"]))
+	assert.JSONEq(t, `"[REDACTED]"`, string(redacted.Args["password"]))
+	// The original request is untouched
+	assert.Equal(t, "token", request.AccessToken)
+	assert.JSONEq(t, `"secret"`, string(request.Args["password"]))
+
+	redacted = redactor.Request(&Request{Resolver: "User.secret", Parents: json.RawMessage(`[{"name": "alice", "password": "secret"}]`)})
+	assert.JSONEq(t, `[{"name": "alice", "password": "[REDACTED]"}]`, string(redacted.Parents))
+
+	event := &Event{TypeName: "User", Operation: "add", Add: &AddEventInfo{Input: []map[string]interface{}{{"name": "alice", "password": "secret"}}}}
+	redacted = redactor.Request(&Request{Resolver: "$webhook", Event: event})
+	assert.Equal(t, Redacted, redacted.Event.Add.Input[0]["password"])
+	assert.Equal(t, "secret", event.Add.Input[0]["password"])
+}
@@ -61,6 +61,15 @@ type DeleteEventInfo struct {
 	RootUIDs []string `json:"rootUIDs"`
 }
 
+// DecodeEvent converts a webhook event into one of the generated typed events.
+func DecodeEvent(event *Event, v interface{}) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
 type MiddlewareFunc func(MiddlewareContext) MiddlewareContext
 
 type MiddlewareContext struct {
@@ -15,16 +15,55 @@ import (
 	"github.com/go-chi/chi/middleware"
 )
 
+// ReplayHeader marks replayed webhook requests, which skip duplicate detection. Its value must match the
+// ReplaySecret of the WebhookOptions, otherwise the request is queued like any other.
+const ReplayHeader = "X-Lambda-Replay"
+
+// ReplaySecretEnv holds the replay secret for the deadletter command.
+const ReplaySecretEnv = "LAMBDA_REPLAY_SECRET"
+
 type ExecuterInterface interface {
 	Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError)
 }
 
+// ExecuterOptions configure generated executers. Unset options use the sinks of lambda.yaml, DefaultEventBus
+// and DefaultLogger.
+type ExecuterOptions struct {
+	Sinks    *SinkRouter
+	EventBus *EventBus
+	Logger   Logger
+}
+
 type Lambda struct {
 	Executor ExecuterInterface
+	Webhooks *WebhookQueue
+	Redactor *Redactor
+	Recorder *Recorder
+	// Debug logs the redacted request of every failing resolver
+	Debug bool
 }
 
 func New(executer ExecuterInterface) *Lambda {
-	return &Lambda{Executor: executer}
+	redactor := NewRedactor()
+	if provider, ok := executer.(RedactionProvider); ok {
+		redactor.Add(provider.Redactions()...)
+	}
+	return &Lambda{Executor: executer, Redactor: redactor}
+}
+
+// EnableAsyncWebhooks acknowledges $webhook requests immediately and resolves them in the background.
+func (l *Lambda) EnableAsyncWebhooks(options WebhookOptions) *WebhookQueue {
+	// The executor is read on every event, so wrappers like the audit log apply however late they are enabled
+	l.Webhooks = NewWebhookQueue(executerFunc(func(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+		return l.Executor.Resolve(ctx, request)
+	}), options)
+	return l.Webhooks
+}
+
+type executerFunc func(ctx context.Context, request *Request) ([]byte, *LambdaError)
+
+func (f executerFunc) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	return f(ctx, request)
 }
 
 func (l *Lambda) Route(w http.ResponseWriter, r *http.Request) {
@@ -53,7 +92,36 @@ func (l *Lambda) resolve(w http.ResponseWriter, r *http.Request) ([]byte, *Lambd
 		return nil, &LambdaError{Underlying: errors.Wrap(err, "Invalid request"), Status: http.StatusBadRequest}
 	}
 
-	return l.Executor.Resolve(r.Context(), request)
+	if request.Resolver == "$webhook" && l.Webhooks != nil {
+		enqueue := l.Webhooks.Enqueue
+		if l.Webhooks.isReplay(r.Header.Get(ReplayHeader)) {
+			enqueue = l.Webhooks.Replay
+		}
+		if err := enqueue(request); err != nil {
+			return nil, &LambdaError{Underlying: err, Status: http.StatusServiceUnavailable}
+		}
+		return nil, nil
+	}
+
+	res, lambdaErr := l.Executor.Resolve(r.Context(), request)
+	if lambdaErr != nil && l.Debug {
+		l.logRequest(request)
+	}
+	if l.Recorder != nil {
+		if err := l.Recorder.Record(request, res, lambdaErr); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+	return res, lambdaErr
+}
+
+func (l *Lambda) logRequest(request *Request) {
+	b, err := json.Marshal(l.Redactor.Request(request))
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Printf("Failed request: %s\n", string(b))
 }
 
 func (l *Lambda) validate(request *Request) error {
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Sink forwards webhook events to another system.
+type Sink interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+// EventEncoder encodes an event and returns the encoded event with its content type.
+type EventEncoder func(event *Event) ([]byte, string, error)
+
+func JSONEncoder(event *Event) ([]byte, string, error) {
+	b, err := json.Marshal(event)
+	return b, "application/json", err
+}
+
+// CloudEventsEncoder encodes events in the structured content mode of CloudEvents 1.0. The event type
+// is <prefix>.<TypeName>.<operation>.
+func CloudEventsEncoder(source string, prefix string) EventEncoder {
+	return func(event *Event) ([]byte, string, error) {
+		b, err := json.Marshal(struct {
+			SpecVersion     string    `json:"specversion"`
+			ID              string    `json:"id"`
+			Source          string    `json:"source"`
+			Type            string    `json:"type"`
+			Subject         string    `json:"subject,omitempty"`
+			Time            time.Time `json:"time"`
+			DataContentType string    `json:"datacontenttype"`
+			Data            *Event    `json:"data"`
+		}{
+			SpecVersion:     "1.0",
+			ID:              fmt.Sprintf("%s-%s-%d", event.TypeName, event.Operation, event.CommitTs),
+			Source:          source,
+			Type:            fmt.Sprintf("%s.%s.%s", prefix, event.TypeName, event.Operation),
+			Subject:         strings.Join(rootUIDs(event), ","),
+			Time:            time.Now().UTC(),
+			DataContentType: "application/json",
+			Data:            event,
+		})
+		return b, "application/cloudevents+json", err
+	}
+}
+
+// FileSink appends every encoded event as a single line to a file.
+type FileSink struct {
+	Filename string
+	Encoder  EventEncoder
+	mu       sync.Mutex
+}
+
+func NewFileSink(filename string, encoder EventEncoder) *FileSink {
+	if encoder == nil {
+		encoder = JSONEncoder
+	}
+	return &FileSink{Filename: filename, Encoder: encoder}
+}
+
+func (s *FileSink) Publish(ctx context.Context, event *Event) error {
+	b, _, err := s.Encoder(event)
+	if err != nil {
+		return errors.Wrap(err, "Could not encode event")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dir := filepath.Dir(s.Filename); dir != "" {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return errors.Wrap(err, "Could not create sink directory")
+		}
+	}
+	f, err := os.OpenFile(s.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "Could not open sink file")
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// HTTPSink posts every encoded event to a URL. Environment variables in the URL and header values are expanded.
+type HTTPSink struct {
+	URL     string
+	Headers map[string]string
+	Encoder EventEncoder
+	Client  *http.Client
+}
+
+func NewHTTPSink(url string, headers map[string]string, encoder EventEncoder) *HTTPSink {
+	if encoder == nil {
+		encoder = JSONEncoder
+	}
+	return &HTTPSink{URL: url, Headers: headers, Encoder: encoder, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, event *Event) error {
+	b, contentType, err := s.Encoder(event)
+	if err != nil {
+		return errors.Wrap(err, "Could not encode event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, os.ExpandEnv(s.URL), bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range s.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "Could not publish event to %s", s.URL)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(res.Body)
+		return errors.Errorf("Could not publish event to %s: %s %s", s.URL, res.Status, string(msg))
+	}
+	return nil
+}
+
+type SinkRoute struct {
+	TypeName   string
+	Operations []string
+	Sink       Sink
+}
+
+func (r *SinkRoute) matches(event *Event) bool {
+	return matchesEvent(r.TypeName, r.Operations, event)
+}
+
+// SinkRouter publishes events to all sinks registered for their type and operation.
+type SinkRouter struct {
+	routes []*SinkRoute
+}
+
+func NewSinkRouter() *SinkRouter {
+	return &SinkRouter{}
+}
+
+// Add registers a sink for a type and operations. An empty type name or no operations match all events.
+func (r *SinkRouter) Add(typeName string, operations []string, sink Sink) {
+	r.routes = append(r.routes, &SinkRoute{TypeName: typeName, Operations: operations, Sink: sink})
+}
+
+func (r *SinkRouter) Publish(ctx context.Context, event *Event) error {
+	if r == nil || event == nil {
+		return nil
+	}
+
+	var failed []string
+	for _, route := range r.routes {
+		if !route.matches(event) {
+			continue
+		}
+		if err := route.Sink.Publish(ctx, event); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
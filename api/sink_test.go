@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SinkRouter_Routes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sink")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var received map[string]interface{}
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	router := NewSinkRouter()
+	router.Add("User", []string{"add"}, NewFileSink(filepath.Join(dir, "user.jsonl"), nil))
+	router.Add("", nil, NewHTTPSink(server.URL, nil, CloudEventsEncoder("test", "dgraph")))
+
+	assert.NoError(t, router.Publish(context.Background(), &Event{TypeName: "User", Operation: "add", CommitTs: 1}))
+	assert.NoError(t, router.Publish(context.Background(), &Event{TypeName: "User", Operation: "delete", CommitTs: 2}))
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "user.jsonl"))
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(b)), "\n"), 1)
+
+	assert.Equal(t, "application/cloudevents+json", contentType)
+	assert.Equal(t, "dgraph.User.delete", received["type"])
+	assert.Equal(t, "1.0", received["specversion"])
+}
+
+type failingSink struct{}
+
+func (s failingSink) Publish(ctx context.Context, event *Event) error {
+	return errors.New("unavailable")
+}
+
+func Test_SinkRouter_Failing_Sink(t *testing.T) {
+	dir := t.TempDir()
+
+	router := NewSinkRouter()
+	router.Add("", nil, failingSink{})
+	router.Add("", nil, NewFileSink(filepath.Join(dir, "events.jsonl"), nil))
+
+	// A failing sink does not keep the event from the other sinks
+	err := router.Publish(context.Background(), &Event{TypeName: "User", Operation: "add", CommitTs: 1})
+	assert.EqualError(t, err, "unavailable")
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "events.jsonl"))
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(b)), "\n"), 1)
+}
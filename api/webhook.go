@@ -0,0 +1,389 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var ErrQueueFull = errors.New("webhook queue is full")
+var ErrQueueClosed = errors.New("webhook queue is closed")
+
+type WebhookOptions struct {
+	Workers     int
+	QueueSize   int
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	DeadLetter  DeadLetterStore
+	// Redactor redacts dead letters before they are stored. Without it the original request is stored, so replayed
+	// dead letters carry the auth header, access token and secrets the resolvers need.
+	Redactor *Redactor
+	// ReplaySecret enables replays over http, see ReplayHeader. Without it the replay header is ignored.
+	ReplaySecret string
+	// Handled persists the handled events per type, operation and root UID.
+	// Without a store events are only deduplicated in memory.
+	Handled    HandledStore
+	DedupeSize int
+}
+
+// WebhookQueue acknowledges webhook requests immediately and resolves them on a bounded
+// worker pool. Failed events are retried with exponential backoff and written to the
+// dead-letter store once all retries are exhausted.
+//
+// Events are deduplicated on type, operation and commitTs. An event waits for the events queued before it
+// with any of its root UIDs, so events for the same node are processed in order while different nodes are
+// processed in parallel.
+type WebhookQueue struct {
+	executer ExecuterInterface
+	options  WebhookOptions
+	queue    chan *queuedRequest
+	// last holds the completion of the last queued event per root UID
+	last     map[string]chan struct{}
+	seen     *dedupe
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	closed   bool
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+type queuedRequest struct {
+	request *Request
+	replay  bool
+	uids    []string
+	after   []chan struct{}
+	done    chan struct{}
+}
+
+func NewWebhookQueue(executer ExecuterInterface, options WebhookOptions) *WebhookQueue {
+	if options.Workers <= 0 {
+		options.Workers = 4
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = 100
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+	if options.BaseBackoff <= 0 {
+		options.BaseBackoff = 500 * time.Millisecond
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = 30 * time.Second
+	}
+	if options.DeadLetter == nil {
+		options.DeadLetter = NewFileDeadLetterStore("deadletter")
+	}
+	if options.DedupeSize <= 0 {
+		options.DedupeSize = 10000
+	}
+
+	q := &WebhookQueue{
+		executer: executer,
+		options:  options,
+		queue:    make(chan *queuedRequest, options.QueueSize),
+		last:     make(map[string]chan struct{}),
+		seen:     newDedupe(options.DedupeSize),
+		done:     make(chan struct{}),
+	}
+
+	for i := 0; i < options.Workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue queues the webhook request unless an event with the same type, operation and commitTs
+// has already been queued or processed.
+func (q *WebhookQueue) Enqueue(request *Request) error {
+	return q.enqueue(request, false)
+}
+
+// Replay queues the webhook request without checking for duplicates or handled events.
+func (q *WebhookQueue) Replay(request *Request) error {
+	return q.enqueue(request, true)
+}
+
+func (q *WebhookQueue) isReplay(header string) bool {
+	return q.options.ReplaySecret != "" && subtle.ConstantTimeCompare([]byte(header), []byte(q.options.ReplaySecret)) == 1
+}
+
+func (q *WebhookQueue) enqueue(request *Request, replay bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	key, dedupe := eventKey(request.Event)
+	dedupe = dedupe && !replay
+	if dedupe && q.seen.contains(key) {
+		fmt.Printf("Webhook %s is a duplicate - skipping\n", describe(request))
+		return nil
+	}
+
+	queued := &queuedRequest{request: request, replay: replay, uids: orderKeys(request.Event), done: make(chan struct{})}
+	for _, uid := range queued.uids {
+		if last, ok := q.last[uid]; ok {
+			queued.after = append(queued.after, last)
+		}
+	}
+
+	select {
+	case q.queue <- queued:
+		// Only accepted events are ordered and remembered
+		for _, uid := range queued.uids {
+			q.last[uid] = queued.done
+		}
+		if dedupe {
+			q.seen.add(key)
+		}
+		return nil
+	default:
+		// Dgraph does not retry webhooks, so a full queue only fails the request if the event could not be kept
+		if err := q.deadLetter(request, ErrQueueFull, 0); err != nil {
+			return ErrQueueFull
+		}
+		return nil
+	}
+}
+
+// Shutdown stops accepting new events and waits for queued events to be processed.
+// Events still waiting for a retry when ctx expires are written to the dead-letter store.
+// Shutdown returns when ctx expires, even if a resolver is still running.
+func (q *WebhookQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		q.doneOnce.Do(func() { close(q.done) })
+		return ctx.Err()
+	}
+}
+
+func (q *WebhookQueue) work() {
+	defer q.wg.Done()
+
+	for queued := range q.queue {
+		// Earlier events were dequeued before this one, so waiting for them cannot deadlock
+		for _, after := range queued.after {
+			<-after
+		}
+
+		if !queued.replay && q.handled(queued.request) {
+			fmt.Printf("Webhook %s was already handled - skipping\n", describe(queued.request))
+		} else if q.process(queued.request) {
+			q.markHandled(queued.request)
+		}
+		q.finish(queued)
+	}
+}
+
+func (q *WebhookQueue) finish(queued *queuedRequest) {
+	close(queued.done)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, uid := range queued.uids {
+		if q.last[uid] == queued.done {
+			delete(q.last, uid)
+		}
+	}
+}
+
+func (q *WebhookQueue) process(request *Request) bool {
+	var lastErr *LambdaError
+	// Retries skip the webhook resolvers that already succeeded for this event
+	ctx := context.WithValue(context.Background(), completedHooksKey{}, &completedHooks{done: make(map[string]bool)})
+
+	for attempt := 0; attempt <= q.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(q.backoff(attempt)):
+			case <-q.done:
+				q.deadLetter(request, lastErr, attempt)
+				return false
+			}
+		}
+
+		_, lastErr = q.executer.Resolve(ctx, request)
+		if lastErr == nil {
+			return true
+		}
+		fmt.Printf("Webhook %s failed (attempt %d): %s\n", describe(request), attempt+1, lastErr.Error())
+
+		if !retryable(lastErr) {
+			q.deadLetter(request, lastErr, attempt+1)
+			return false
+		}
+	}
+	q.deadLetter(request, lastErr, q.options.MaxRetries+1)
+	return false
+}
+
+// An event was handled if its commitTs was handled for every root UID. Events with a lower commitTs arriving
+// late are still processed.
+func (q *WebhookQueue) handled(request *Request) bool {
+	if q.options.Handled == nil || request.Event == nil || request.Event.CommitTs == 0 {
+		return false
+	}
+	uids := rootUIDs(request.Event)
+	if len(uids) == 0 {
+		return false
+	}
+	for _, uid := range uids {
+		handled, err := q.options.Handled.Handled(handledKey(request.Event, uid), request.Event.CommitTs)
+		if err != nil {
+			fmt.Printf("Could not read handled events for webhook %s: %s\n", describe(request), err.Error())
+			return false
+		}
+		if !handled {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *WebhookQueue) markHandled(request *Request) {
+	if q.options.Handled == nil || request.Event == nil || request.Event.CommitTs == 0 {
+		return
+	}
+	for _, uid := range rootUIDs(request.Event) {
+		if err := q.options.Handled.MarkHandled(handledKey(request.Event, uid), request.Event.CommitTs); err != nil {
+			fmt.Printf("Could not store handled event for webhook %s: %s\n", describe(request), err.Error())
+		}
+	}
+}
+
+func (q *WebhookQueue) backoff(attempt int) time.Duration {
+	backoff := q.options.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= q.options.MaxBackoff {
+			return q.options.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+func (q *WebhookQueue) deadLetter(request *Request, err error, attempts int) error {
+	letter := &DeadLetter{
+		Request:  request,
+		Attempts: attempts,
+		FailedAt: time.Now().UTC(),
+	}
+	if q.options.Redactor != nil {
+		letter.Request = q.options.Redactor.Request(request)
+	}
+	if err != nil {
+		letter.Error = err.Error()
+	}
+	if storeErr := q.options.DeadLetter.Put(letter); storeErr != nil {
+		fmt.Printf("Could not store dead letter for webhook %s: %s\n", describe(request), storeErr.Error())
+		return storeErr
+	}
+	return nil
+}
+
+// Client errors will fail the same way on every attempt, so only server errors are retried.
+func retryable(err *LambdaError) bool {
+	return err.Status == 0 || int(err.Status) >= http.StatusInternalServerError
+}
+
+func eventKey(event *Event) (string, bool) {
+	if event == nil || event.CommitTs == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s/%d", event.TypeName, event.Operation, event.CommitTs), true
+}
+
+func handledKey(event *Event, uid string) string {
+	return fmt.Sprintf("%s/%s/%s", event.TypeName, event.Operation, uid)
+}
+
+// orderKeys returns the keys an event is ordered by, its root UIDs or its type if it has none.
+func orderKeys(event *Event) []string {
+	if uids := rootUIDs(event); len(uids) > 0 {
+		return uids
+	}
+	if event != nil {
+		return []string{event.TypeName}
+	}
+	return nil
+}
+
+func rootUIDs(event *Event) []string {
+	if event == nil {
+		return nil
+	}
+	var uids []string
+	if event.Add != nil {
+		uids = append(uids, event.Add.RootUIDs...)
+	}
+	if event.Update != nil {
+		uids = append(uids, event.Update.RootUIDs...)
+	}
+	if event.Delete != nil {
+		uids = append(uids, event.Delete.RootUIDs...)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+func describe(request *Request) string {
+	if request.Event == nil {
+		return request.Resolver
+	}
+	return fmt.Sprintf("%s.%s@%d", request.Event.TypeName, request.Event.Operation, request.Event.CommitTs)
+}
+
+type completedHooksKey struct{}
+
+type completedHooks struct {
+	mu   sync.Mutex
+	done map[string]bool
+}
+
+// HookCompleted reports whether a webhook resolver already succeeded in an earlier attempt of the queued event.
+func HookCompleted(ctx context.Context, name string) bool {
+	hooks, ok := ctx.Value(completedHooksKey{}).(*completedHooks)
+	if !ok {
+		return false
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	return hooks.done[name]
+}
+
+// CompleteHook marks a webhook resolver as succeeded for the queued event, so retries of the event skip it.
+func CompleteHook(ctx context.Context, name string) {
+	hooks, ok := ctx.Value(completedHooksKey{}).(*completedHooks)
+	if !ok {
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.done[name] = true
+}
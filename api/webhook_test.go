@@ -0,0 +1,330 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingExecuter struct {
+	calls  int32
+	status HttpResponseStatus
+}
+
+func (e *failingExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	atomic.AddInt32(&e.calls, 1)
+	return nil, &LambdaError{Underlying: errors.New("failed"), Status: e.status}
+}
+
+func newTestDeadLetterStore(t *testing.T) *FileDeadLetterStore {
+	dir, err := ioutil.TempDir("", "deadletter")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return NewFileDeadLetterStore(dir)
+}
+
+func Test_WebhookQueue_Retries_DeadLetter(t *testing.T) {
+	store := newTestDeadLetterStore(t)
+	executer := &failingExecuter{status: http.StatusInternalServerError}
+
+	queue := NewWebhookQueue(executer, WebhookOptions{Workers: 1, MaxRetries: 2, BaseBackoff: time.Millisecond, DeadLetter: store})
+	err := queue.Enqueue(&Request{Resolver: "$webhook", Event: &Event{TypeName: "User", Operation: "add", CommitTs: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&executer.calls))
+
+	letters, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Equal(t, "User", letters[0].Request.Event.TypeName)
+
+	assert.NoError(t, store.Delete(letters[0].ID))
+	letters, err = store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 0)
+}
+
+func Test_WebhookQueue_No_Retry_Client_Error(t *testing.T) {
+	store := newTestDeadLetterStore(t)
+	executer := &failingExecuter{status: http.StatusNotFound}
+
+	queue := NewWebhookQueue(executer, WebhookOptions{Workers: 1, MaxRetries: 5, BaseBackoff: time.Millisecond, DeadLetter: store})
+	assert.NoError(t, queue.Enqueue(&Request{Resolver: "$webhook", Event: &Event{TypeName: "User"}}))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&executer.calls))
+	letters, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, ErrQueueClosed, queue.Enqueue(&Request{Resolver: "$webhook", Event: &Event{}}))
+}
+
+func Test_Route_Async_Webhook(t *testing.T) {
+	store := newTestDeadLetterStore(t)
+	executer := &failingExecuter{status: http.StatusInternalServerError}
+
+	lambda := New(executer)
+	lambda.Redactor.Add("User.password")
+	queue := lambda.EnableAsyncWebhooks(WebhookOptions{MaxRetries: 0, DeadLetter: store})
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql-worker", bytes.NewBufferString(`{ "resolver":"$webhook", "authHeader": { "key": "Auth", "value": "token" },
+		"event": { "__typename": "User", "operation": "add", "add": { "input": [{ "password": "secret" }] } } }`))
+	w := httptest.NewRecorder()
+	lambda.Route(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	assert.NoError(t, queue.Shutdown(context.Background()))
+	letters, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+
+	// Dead letters keep the original request, so they can be replayed
+	assert.Equal(t, "token", letters[0].Request.AuthHeader.Value)
+	assert.Equal(t, "secret", letters[0].Request.Event.Add.Input[0]["password"])
+
+	info, err := os.Stat(store.filename(letters[0].ID))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func Test_WebhookQueue_Redacted_DeadLetter(t *testing.T) {
+	store := newTestDeadLetterStore(t)
+	executer := &failingExecuter{status: http.StatusInternalServerError}
+
+	queue := NewWebhookQueue(executer, WebhookOptions{MaxRetries: 0, DeadLetter: store, Redactor: NewRedactor("User.password")})
+	assert.NoError(t, queue.Enqueue(&Request{Resolver: "$webhook", AuthHeader: AuthHeader{Key: "Auth", Value: "token"},
+		Event: &Event{TypeName: "User", Operation: "add", Add: &AddEventInfo{Input: []map[string]interface{}{{"password": "secret"}}}}}))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	letters, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, Redacted, letters[0].Request.AuthHeader.Value)
+	assert.Equal(t, Redacted, letters[0].Request.Event.Add.Input[0]["password"])
+}
+
+type recordingExecuter struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+func (e *recordingExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, request.Event)
+	return nil, nil
+}
+
+func updateEvent(uid string, commitTs uint64) *Request {
+	return &Request{Resolver: "$webhook", Event: &Event{TypeName: "User", Operation: "update", CommitTs: commitTs, Update: &UpdateEventInfo{RootUIDs: []string{uid}}}}
+}
+
+func Test_WebhookQueue_Dedupe_Ordered(t *testing.T) {
+	executer := &recordingExecuter{}
+	queue := NewWebhookQueue(executer, WebhookOptions{Workers: 4, DeadLetter: newTestDeadLetterStore(t)})
+
+	for ts := uint64(1); ts <= 20; ts++ {
+		assert.NoError(t, queue.Enqueue(updateEvent(fmt.Sprintf("0x%d", ts%3), ts)))
+	}
+	// Duplicates of already queued events
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 1)))
+	assert.NoError(t, queue.Enqueue(updateEvent("0x2", 2)))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Len(t, executer.events, 20)
+
+	last := map[string]uint64{}
+	for _, event := range executer.events {
+		uid := event.Update.RootUIDs[0]
+		assert.Greater(t, event.CommitTs, last[uid])
+		last[uid] = event.CommitTs
+	}
+}
+
+func Test_WebhookQueue_Handled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "handled")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	handled, err := NewFileHandledStore(filepath.Join(dir, "handled.log"), 0)
+	assert.NoError(t, err)
+
+	executer := &recordingExecuter{}
+	queue := NewWebhookQueue(executer, WebhookOptions{Handled: handled, DeadLetter: newTestDeadLetterStore(t)})
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 5)))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+	assert.Len(t, executer.events, 1)
+
+	// Restart with the persisted handled events
+	handled, err = NewFileHandledStore(filepath.Join(dir, "handled.log"), 0)
+	assert.NoError(t, err)
+
+	executer = &recordingExecuter{}
+	queue = NewWebhookQueue(executer, WebhookOptions{Workers: 1, Handled: handled, DeadLetter: newTestDeadLetterStore(t)})
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 5)))
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 6)))
+	// A late event with a lower commitTs is not lost
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 3)))
+	assert.NoError(t, queue.Replay(updateEvent("0x1", 5)))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Len(t, executer.events, 3)
+	assert.Equal(t, uint64(6), executer.events[0].CommitTs)
+	assert.Equal(t, uint64(3), executer.events[1].CommitTs)
+	assert.Equal(t, uint64(5), executer.events[2].CommitTs)
+}
+
+func Test_FileHandledStore_Compact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "handled")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "handled.log")
+
+	handled, err := NewFileHandledStore(filename, 2)
+	assert.NoError(t, err)
+	for ts := uint64(1); ts <= 4; ts++ {
+		assert.NoError(t, handled.MarkHandled("User/update/0x1", ts))
+	}
+
+	b, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, "User/update/0x1@3\nUser/update/0x1@4\n", string(b))
+
+	handled, err = NewFileHandledStore(filename, 2)
+	assert.NoError(t, err)
+	ok, _ := handled.Handled("User/update/0x1", 1)
+	assert.False(t, ok)
+	ok, _ = handled.Handled("User/update/0x1", 4)
+	assert.True(t, ok)
+}
+
+func Test_WebhookQueue_Ordered_Multiple_UIDs(t *testing.T) {
+	executer := &recordingExecuter{}
+	queue := NewWebhookQueue(executer, WebhookOptions{Workers: 4, DeadLetter: newTestDeadLetterStore(t)})
+
+	for ts := uint64(1); ts <= 30; ts++ {
+		request := updateEvent(fmt.Sprintf("0x%d", ts%5), ts)
+		request.Event.Update.RootUIDs = append(request.Event.Update.RootUIDs, fmt.Sprintf("0xa%d", ts%3))
+		assert.NoError(t, queue.Enqueue(request))
+	}
+	assert.NoError(t, queue.Shutdown(context.Background()))
+	assert.Len(t, executer.events, 30)
+
+	last := map[string]uint64{}
+	for _, event := range executer.events {
+		for _, uid := range event.Update.RootUIDs {
+			assert.Greater(t, event.CommitTs, last[uid])
+			last[uid] = event.CommitTs
+		}
+	}
+}
+
+type blockingExecuter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	e.started <- struct{}{}
+	<-e.release
+	return nil, nil
+}
+
+func Test_WebhookQueue_Full_Shutdown(t *testing.T) {
+	store := newTestDeadLetterStore(t)
+	executer := &blockingExecuter{started: make(chan struct{}, 3), release: make(chan struct{})}
+	defer close(executer.release)
+
+	queue := NewWebhookQueue(executer, WebhookOptions{Workers: 1, QueueSize: 1, DeadLetter: store})
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 1)))
+	<-executer.started
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 2)))
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 3)))
+
+	// The first event blocks the worker, the second is queued and the third is dead-lettered
+	letters, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+
+	// Dead-lettered events are not remembered as duplicates
+	assert.NoError(t, queue.Enqueue(updateEvent("0x1", 3)))
+	letters, err = store.List()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Equal(t, context.DeadlineExceeded, queue.Shutdown(ctx))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func Test_Route_Replay_Secret(t *testing.T) {
+	handled, err := NewFileHandledStore(filepath.Join(t.TempDir(), "handled.log"), 0)
+	assert.NoError(t, err)
+	assert.NoError(t, handled.MarkHandled(handledKey(updateEvent("0x1", 5).Event, "0x1"), 5))
+
+	executer := &recordingExecuter{}
+	lambda := New(executer)
+	queue := lambda.EnableAsyncWebhooks(WebhookOptions{Workers: 1, Handled: handled, ReplaySecret: "secret", DeadLetter: newTestDeadLetterStore(t)})
+
+	for _, header := range []string{"", "true", "wrong", "secret"} {
+		body, _ := json.Marshal(updateEvent("0x1", 5))
+		req := httptest.NewRequest(http.MethodPost, "/graphql-worker", bytes.NewBuffer(body))
+		if header != "" {
+			req.Header.Set(ReplayHeader, header)
+		}
+		w := httptest.NewRecorder()
+		lambda.Route(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	}
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	// Only the replay with the secret skips the handled check
+	assert.Len(t, executer.events, 1)
+}
+
+type hooksExecuter struct {
+	calls map[string]int
+	fail  int
+}
+
+func (e *hooksExecuter) Resolve(ctx context.Context, request *Request) ([]byte, *LambdaError) {
+	for _, hook := range []string{"Webhook_Question_add", "Webhook_Post_add"} {
+		if HookCompleted(ctx, hook) {
+			continue
+		}
+		e.calls[hook]++
+		if hook == "Webhook_Post_add" && e.fail > 0 {
+			e.fail--
+			return nil, &LambdaError{Underlying: errors.New("failed"), Status: http.StatusInternalServerError}
+		}
+		CompleteHook(ctx, hook)
+	}
+	return nil, nil
+}
+
+func Test_WebhookQueue_Retry_Completed_Hooks(t *testing.T) {
+	executer := &hooksExecuter{calls: make(map[string]int), fail: 2}
+	queue := NewWebhookQueue(executer, WebhookOptions{Workers: 1, MaxRetries: 3, BaseBackoff: time.Millisecond, DeadLetter: newTestDeadLetterStore(t)})
+	assert.NoError(t, queue.Enqueue(&Request{Resolver: "$webhook", Event: &Event{TypeName: "Question", Operation: "add"}}))
+	assert.NoError(t, queue.Shutdown(context.Background()))
+
+	// The type webhook succeeded in the first attempt and is not called again by the retries
+	assert.Equal(t, map[string]int{"Webhook_Question_add": 1, "Webhook_Post_add": 3}, executer.calls)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/urfave/cli/v2"
+)
+
+var deadLetterFlags = []cli.Flag{
+	&cli.StringFlag{Name: "dir", Aliases: []string{"d"}, Value: "deadletter", Usage: "the dead letter directory"},
+}
+
+var deadLetterCmd = &cli.Command{
+	Name:        "deadletter",
+	Usage:       "deadletter list|replay",
+	Description: "inspects and replays webhook events that could not be processed",
+	Subcommands: []*cli.Command{
+		{
+			Name:        "list",
+			Usage:       "list -d \"deadletter\"",
+			Description: "lists stored dead letters",
+			Flags:       deadLetterFlags,
+			Action: func(ctx *cli.Context) error {
+				store := api.NewFileDeadLetterStore(ctx.String("dir"))
+
+				letters, err := store.List()
+				if err != nil {
+					return err
+				}
+				for _, letter := range letters {
+					fmt.Printf("%s\tattempts: %d\t%s\n", letter.ID, letter.Attempts, letter.Error)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "replay",
+			Usage:       "replay -d \"deadletter\" -u \"http://localhost:8686/graphql-worker\"",
+			Description: "sends stored dead letters to a running lambda server and removes them on success",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{Name: "url", Aliases: []string{"u"}, Value: "http://localhost:8686/graphql-worker", Usage: "the lambda endpoint"},
+				&cli.StringFlag{Name: "id", Usage: "only replay the dead letter with this id"},
+				&cli.StringFlag{Name: "secret", EnvVars: []string{api.ReplaySecretEnv}, Usage: "the replay secret of the lambda server, without it replays are deduplicated"},
+			}, deadLetterFlags...),
+			Action: func(ctx *cli.Context) error {
+				store := api.NewFileDeadLetterStore(ctx.String("dir"))
+
+				letters, err := store.List()
+				if err != nil {
+					return err
+				}
+
+				failed := 0
+				for _, letter := range letters {
+					if id := ctx.String("id"); id != "" && id != letter.ID {
+						continue
+					}
+					if err := replayDeadLetter(ctx.String("url"), ctx.String("secret"), letter); err != nil {
+						fmt.Printf("%s\tfailed: %s\n", letter.ID, err.Error())
+						failed++
+						continue
+					}
+					if err := store.Delete(letter.ID); err != nil {
+						return err
+					}
+					fmt.Printf("%s\treplayed\n", letter.ID)
+				}
+				if failed > 0 {
+					return errors.Errorf("%d dead letters could not be replayed", failed)
+				}
+				return nil
+			},
+		},
+	},
+}
+
+func replayDeadLetter(url string, secret string, letter *api.DeadLetter) error {
+	body, err := json.Marshal(letter.Request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(api.ReplayHeader, secret)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(res.Body)
+		return errors.Errorf("%s: %s", res.Status, string(msg))
+	}
+	return nil
+}
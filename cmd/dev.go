@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/codegen/config"
+	"github.com/schartey/dgraph-lambda-go/dev"
+	"github.com/schartey/dgraph-lambda-go/internal"
+	"github.com/urfave/cli/v2"
+)
+
+var devCmd = &cli.Command{
+	Name:        "dev",
+	Usage:       "dev --seed-data seed.json",
+	Description: "serves a GraphQL endpoint that calls the lambda server like Dgraph and reads all other data from seed data",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Value: "lambda.yaml", Usage: "the lambda config file"},
+		&cli.IntFlag{Name: "port", Aliases: []string{"p"}, Value: 8080, Usage: "the port of the GraphQL endpoint"},
+		&cli.StringFlag{Name: "url", Aliases: []string{"u"}, Value: "http://localhost:8686/graphql-worker", Usage: "the lambda endpoint"},
+		&cli.StringFlag{Name: "seed-data", Aliases: []string{"s"}, Value: "seed.json", Usage: "the stored data as json object of type names to objects"},
+		&cli.StringFlag{Name: "auth-header", Value: "Authorization", Usage: "the header forwarded as auth header, like Dgraph.Authorization"},
+	},
+	Action: func(ctx *cli.Context) error {
+		configFile := ctx.String("config")
+		moduleName, err := internal.GetModuleName()
+		if err != nil {
+			return err
+		}
+		config, err := config.LoadConfigFile(moduleName, configFile)
+		if err != nil {
+			return err
+		}
+		if err := config.LoadSources(configFile); err != nil {
+			return err
+		}
+
+		schema, err := dev.LoadSchema(config.Sources)
+		if err != nil {
+			return err
+		}
+		seedData, err := dev.LoadSeedData(ctx.String("seed-data"))
+		if err != nil {
+			return err
+		}
+
+		server := dev.NewServer(schema, seedData, &api.RemoteExecuter{URL: ctx.String("url")})
+		server.AuthHeader = ctx.String("auth-header")
+
+		mux := http.NewServeMux()
+		mux.Handle("/graphql", server)
+
+		fmt.Printf("GraphQL endpoint listening on http://localhost:%d/graphql\n", ctx.Int("port"))
+		return http.ListenAndServe(fmt.Sprintf(":%d", ctx.Int("port")), mux)
+	},
+}
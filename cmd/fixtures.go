@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/schartey/dgraph-lambda-go/codegen/config"
+	"github.com/schartey/dgraph-lambda-go/codegen/fixtures"
+	"github.com/schartey/dgraph-lambda-go/codegen/parser"
+	"github.com/schartey/dgraph-lambda-go/internal"
+	"github.com/urfave/cli/v2"
+)
+
+var fixturesCmd = &cli.Command{
+	Name:        "fixtures",
+	Usage:       "fixtures -o requests.json",
+	Description: "generates an example request for every resolver and webhook event of the schema in lambda.yaml",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Value: "lambda.yaml", Usage: "the lambda config file"},
+		&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "the file the requests are written to, stdout if not set"},
+		&cli.Int64Flag{Name: "seed", Aliases: []string{"s"}, Value: 1, Usage: "the seed of the fake values"},
+	},
+	Action: func(ctx *cli.Context) error {
+		configFile := ctx.String("config")
+
+		moduleName, err := internal.GetModuleName()
+		if err != nil {
+			return err
+		}
+		config, err := config.LoadConfigFile(moduleName, configFile)
+		if err != nil {
+			return err
+		}
+		if err := config.LoadConfig(configFile); err != nil {
+			return err
+		}
+		if err := config.LoadSchema(); err != nil {
+			return err
+		}
+
+		parser := parser.NewParser(config.Schema, config.Packages, config.Force)
+		parsedTree, err := parser.Parse()
+		if err != nil {
+			return err
+		}
+
+		requests := fixtures.NewGenerator(config.Schema, ctx.Int64("seed")).Generate(parsedTree)
+		b, err := json.MarshalIndent(requests, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "Could not marshal requests")
+		}
+
+		if ctx.String("output") == "" {
+			fmt.Println(string(b))
+			return nil
+		}
+		if err := ioutil.WriteFile(ctx.String("output"), b, 0644); err != nil {
+			return errors.Wrap(err, "Could not write requests")
+		}
+		return nil
+	},
+}
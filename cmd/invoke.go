@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/codegen/config"
+	"github.com/schartey/dgraph-lambda-go/codegen/graphql"
+	"github.com/schartey/dgraph-lambda-go/internal"
+	"github.com/urfave/cli/v2"
+)
+
+var invokeCmd = &cli.Command{
+	Name:        "invoke",
+	Usage:       "invoke Query.getHotelByName --args '{\"name\":\"x\"}'",
+	Description: "calls a resolver of a running lambda server with the request Dgraph would send",
+	ArgsUsage:   "<Query.field|Mutation.field|Type.field|$webhook|$webhook.Type.operation>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "url", Aliases: []string{"u"}, Value: "http://localhost:8686/graphql-worker", Usage: "the lambda endpoint"},
+		&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Usage: "the lambda config file"},
+		&cli.StringFlag{Name: "args", Aliases: []string{"a"}, Usage: "the arguments of a query or mutation as json object"},
+		&cli.StringFlag{Name: "parents", Aliases: []string{"p"}, Usage: "the parents of a field resolver as json array"},
+		&cli.StringFlag{Name: "event", Aliases: []string{"e"}, Usage: "the event of a webhook as json object"},
+		&cli.StringFlag{Name: "fixtures", Aliases: []string{"f"}, Usage: "send the request of the resolver from a file generated by the fixtures command"},
+		&cli.StringFlag{Name: "auth-header", Usage: "the auth header as \"Key: Value\""},
+		&cli.StringFlag{Name: "token", Aliases: []string{"t"}, Usage: "the X-Dgraph-AccessToken"},
+	},
+	Action: func(ctx *cli.Context) error {
+		resolver := ctx.Args().First()
+		if resolver == "" {
+			return errors.New("resolver must be set, e.g. Query.getHotelByName")
+		}
+
+		options, err := invokeOptions(ctx)
+		if err != nil {
+			return err
+		}
+
+		var request *api.Request
+		if options["fixtures"] != "" {
+			request, err = fixtureRequest(options["fixtures"], resolver)
+		} else {
+			request, err = buildRequest(options, resolver)
+		}
+		if err != nil {
+			return err
+		}
+		request.AccessToken = options["token"]
+		if header := options["auth-header"]; header != "" {
+			parts := strings.SplitN(header, ":", 2)
+			if len(parts) != 2 {
+				return errors.New("auth header must be of the form \"Key: Value\"")
+			}
+			request.AuthHeader = api.AuthHeader{Key: strings.TrimSpace(parts[0]), Value: strings.TrimSpace(parts[1])}
+		}
+
+		executer := &api.RemoteExecuter{URL: options["url"]}
+		res, lambdaErr := executer.Resolve(context.Background(), request)
+		if lambdaErr != nil {
+			return cli.Exit(fmt.Sprintf("Error %d: %s", lambdaErr.Status, lambdaErr.Error()), 1)
+		}
+
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, res, "", "  "); err != nil {
+			fmt.Println(string(res))
+			return nil
+		}
+		fmt.Println(pretty.String())
+		return nil
+	},
+}
+
+// invokeOptions returns the values of all flags. Flags following the resolver are not parsed by cli, so they are
+// parsed here and take precedence.
+func invokeOptions(ctx *cli.Context) (map[string]string, error) {
+	options := make(map[string]string)
+	names := make(map[string]string)
+	set := flag.NewFlagSet(ctx.Command.Name, flag.ContinueOnError)
+	for _, f := range ctx.Command.Flags {
+		for _, name := range f.Names() {
+			names[name] = f.Names()[0]
+		}
+		options[f.Names()[0]] = ctx.String(f.Names()[0])
+		if err := f.Apply(set); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := set.Parse(ctx.Args().Tail()); err != nil {
+		return nil, err
+	}
+	if set.NArg() > 0 {
+		return nil, errors.Errorf("unexpected arguments %s", strings.Join(set.Args(), " "))
+	}
+	set.Visit(func(f *flag.Flag) {
+		options[names[f.Name]] = f.Value.String()
+	})
+	return options, nil
+}
+
+func fixtureRequest(filename string, resolver string) (*api.Request, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read fixtures")
+	}
+	var requests map[string]*api.Request
+	if err := json.Unmarshal(b, &requests); err != nil {
+		return nil, errors.Wrap(err, "Could not parse fixtures")
+	}
+	request, ok := requests[resolver]
+	if !ok {
+		return nil, errors.Errorf("Could not find %s in fixtures", resolver)
+	}
+	return request, nil
+}
+
+func buildRequest(options map[string]string, resolver string) (*api.Request, error) {
+	if resolver == "$webhook" {
+		if options["event"] == "" {
+			return nil, errors.New("webhooks need an --event")
+		}
+		var event *api.Event
+		if err := json.Unmarshal([]byte(options["event"]), &event); err != nil {
+			return nil, errors.Wrap(err, "Could not parse event")
+		}
+		if event.TypeName == "" || event.Operation == "" {
+			return nil, errors.New("event must have a __typename and an operation")
+		}
+		return &api.Request{Resolver: resolver, Event: event}, nil
+	}
+
+	parts := strings.Split(resolver, ".")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("resolver %s must be of the form Type.field", resolver)
+	}
+
+	configFile := options["config"]
+	if configFile == "" {
+		configFile = "lambda.yaml"
+	}
+	moduleName, err := internal.GetModuleName()
+	if err != nil {
+		return nil, err
+	}
+	config, err := config.LoadConfigFile(moduleName, configFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.LoadSources(configFile); err != nil {
+		return nil, err
+	}
+	if err := config.LoadSchema(); err != nil {
+		return nil, err
+	}
+
+	def := config.Schema.Types[parts[0]]
+	if def == nil {
+		return nil, errors.Errorf("Could not find type %s in schema", parts[0])
+	}
+	field := def.Fields.ForName(parts[1])
+	if field == nil {
+		return nil, errors.Errorf("Could not find field %s in schema", resolver)
+	}
+	if graphql.LambdaField(config.Schema, def, parts[1]) == nil {
+		return nil, errors.Errorf("%s is not a @lambda field", resolver)
+	}
+
+	if def == config.Schema.Query || def == config.Schema.Mutation {
+		rawArgs := options["args"]
+		if rawArgs == "" {
+			rawArgs = "{}"
+		}
+		var args map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
+			return nil, errors.Wrap(err, "args must be a json object")
+		}
+		if err := graphql.ValidateArguments(config.Schema, field, args); err != nil {
+			return nil, err
+		}
+		return &api.Request{Resolver: resolver, Args: args}, nil
+	}
+
+	if options["parents"] == "" {
+		return nil, errors.New("field resolvers need --parents")
+	}
+	var parents []map[string]interface{}
+	if err := json.Unmarshal([]byte(options["parents"]), &parents); err != nil {
+		return nil, errors.Wrap(err, "parents must be a json array of objects")
+	}
+	return &api.Request{Resolver: resolver, Parents: json.RawMessage(options["parents"])}, nil
+}
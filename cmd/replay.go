@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/codegen/config"
+	"github.com/schartey/dgraph-lambda-go/codegen/generator"
+	"github.com/schartey/dgraph-lambda-go/internal"
+	"github.com/urfave/cli/v2"
+)
+
+var replayCmd = &cli.Command{
+	Name:        "replay",
+	Usage:       "replay -d \"recordings\" [-u \"http://localhost:8686/graphql-worker\"]",
+	Description: "replays recorded requests against the generated executer or a running lambda server and compares the responses",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "dir", Aliases: []string{"d"}, Value: "recordings", Usage: "the recording directory"},
+		&cli.StringFlag{Name: "url", Aliases: []string{"u"}, Usage: "replay against a running lambda server instead of the generated executer"},
+		&cli.StringFlag{Name: "id", Usage: "only replay the recording with this id"},
+		&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Usage: "the lambda config file"},
+		&cli.StringFlag{Name: "access-token", EnvVars: []string{api.ReplayAccessTokenEnv}, Usage: "replaces the redacted access token of recordings"},
+		&cli.StringFlag{Name: "auth-header", EnvVars: []string{api.ReplayAuthHeaderEnv}, Usage: "replaces the redacted auth header value of recordings"},
+	},
+	Action: func(ctx *cli.Context) error {
+		if url := ctx.String("url"); url != "" {
+			options := api.ReplayOptions{ID: ctx.String("id"), AccessToken: ctx.String("access-token"), AuthHeader: ctx.String("auth-header")}
+			return api.ReplayRecordings(&api.RemoteExecuter{URL: url}, ctx.String("dir"), options, os.Stdout)
+		}
+
+		configFile := ctx.String("config")
+		if configFile == "" {
+			configFile = "lambda.yaml"
+		}
+
+		moduleName, err := internal.GetModuleName()
+		if err != nil {
+			return err
+		}
+		config, err := config.LoadConfigFile(moduleName, configFile)
+		if err != nil {
+			return err
+		}
+
+		dir, err := filepath.Abs(ctx.String("dir"))
+		if err != nil {
+			return err
+		}
+
+		// The replay program has to be part of the module to import the generated executer
+		tmp, err := ioutil.TempDir(".", ".replay")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+
+		if err := generator.GenerateReplay(config, filepath.Join(tmp, "main.go")); err != nil {
+			return err
+		}
+
+		cmd := exec.Command("go", "run", "./"+filepath.Base(tmp), dir, ctx.String("id"))
+		// The auth is passed in the environment, so it does not show up in the process list
+		cmd.Env = append(os.Environ(), api.ReplayAccessTokenEnv+"="+ctx.String("access-token"), api.ReplayAuthHeaderEnv+"="+ctx.String("auth-header"))
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				// The replay program already printed the differences
+				return cli.Exit("", exitErr.ExitCode())
+			}
+			return err
+		}
+		return nil
+	},
+}
@@ -32,6 +32,11 @@ func Execute() {
 		initCmd,
 		generateCmd,
 		exampleCmd,
+		deadLetterCmd,
+		replayCmd,
+		invokeCmd,
+		devCmd,
+		fixturesCmd,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -33,6 +33,16 @@ type ResolverConfig struct {
 	FilenameTemplate string `yaml:"filename_template"`
 }
 
+type SinkConfig struct {
+	Type       string            `yaml:"type"`
+	Operations []string          `yaml:"operations"`
+	Kind       string            `yaml:"kind"`
+	Filename   string            `yaml:"filename"`
+	URL        string            `yaml:"url"`
+	Source     string            `yaml:"source"`
+	Headers    map[string]string `yaml:"headers"`
+}
+
 type Config struct {
 	SchemaFilename []string       `yaml:"schema"`
 	Exec           PackageConfig  `yaml:"exec"`
@@ -43,6 +53,10 @@ type Config struct {
 	Server         struct {
 		Standalone bool `yaml:"standalone"`
 	} `yaml:"server"`
+	Sinks  []SinkConfig `yaml:"sinks"`
+	Redact []string     `yaml:"redact"`
+	// DgraphTypes expands the schema with the types Dgraph generates, like AddUserInput or UserFilter
+	DgraphTypes bool `yaml:"dgraph_types"`
 
 	Sources             []*ast.Source      `yaml:"-"`
 	Packages            *internal.Packages `yaml:"-"`
@@ -76,6 +90,21 @@ func LoadConfigFile(moduleName string, filename string) (*Config, error) {
 		return nil, errors.New("resovler target direcotry must be set in lambda config")
 	}
 
+	for _, sink := range config.Sinks {
+		switch sink.Kind {
+		case "file":
+			if sink.Filename == "" {
+				return nil, errors.New("file sinks must have a filename")
+			}
+		case "http", "cloudevents":
+			if sink.URL == "" {
+				return nil, errors.New(sink.Kind + " sinks must have a url")
+			}
+		default:
+			return nil, errors.New("sink kind must be one of file, http or cloudevents")
+		}
+	}
+
 	config.Root = moduleName
 
 	resolverTemplateSub := resolverTemplateRegex.FindStringSubmatch(config.Resolver.FilenameTemplate)
@@ -93,6 +122,27 @@ func LoadConfigFile(moduleName string, filename string) (*Config, error) {
 }
 
 func (config *Config) LoadConfig(filename string) error {
+	if err := config.LoadSources(filename); err != nil {
+		return err
+	}
+
+	if config.Packages == nil {
+		config.Packages = &internal.Packages{}
+
+		defaultModelPath := config.Root + "/" + path.Dir(config.Model.Filename)
+
+		defaultPackage, err := config.Packages.Load(defaultModelPath)
+		if err != nil {
+			return errors.Wrap(err, "Could not load generated model package")
+		}
+		config.DefaultModelPackage = defaultPackage
+	}
+
+	return nil
+}
+
+// LoadSources reads the schema files relative to the config file without loading any go packages.
+func (config *Config) LoadSources(filename string) error {
 	preGlobbing := config.SchemaFilename
 
 	abs, err := filepath.Abs(filename)
@@ -127,6 +177,10 @@ func (config *Config) LoadConfig(filename string) error {
 	}
 	config.SchemaFilename = schemaFiles
 
+	// The Dgraph definitions are only added once, so the schema can be split into several files
+	config.Sources = append(config.Sources, &ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs})
+	config.Sources = append(config.Sources, &ast.Source{Input: graphql.ApolloSchemaQueries + graphql.ApolloSchemaExtras})
+
 	for _, filename := range config.SchemaFilename {
 		filename = filepath.ToSlash(filename)
 		var err error
@@ -136,21 +190,27 @@ func (config *Config) LoadConfig(filename string) error {
 			errors.Wrap(err, "unable to open schema")
 		}
 
-		config.Sources = append(config.Sources, &ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs})
-		config.Sources = append(config.Sources, &ast.Source{Input: graphql.ApolloSchemaQueries + graphql.ApolloSchemaExtras})
 		config.Sources = append(config.Sources, &ast.Source{Name: filename, Input: string(schemaRaw)})
 	}
 
-	if config.Packages == nil {
-		config.Packages = &internal.Packages{}
-
-		defaultModelPath := config.Root + "/" + path.Dir(config.Model.Filename)
+	// Inherited fields are added to the sources instead of in loadSchema, as the Dgraph types are generated from the
+	// sources and the dev and invoke commands load their schema from the sources as well. Rewritten sources are
+	// printed by the gqlparser formatter, which keeps descriptions and directives like @middleware and @secret.
+	sources, warnings, err := graphql.InheritInterfaceFields(config.Sources)
+	if err != nil {
+		return errors.Wrap(err, "Could not parse schema")
+	}
+	for _, warning := range warnings {
+		fmt.Println("Warning: " + warning)
+	}
+	config.Sources = sources
 
-		defaultPackage, err := config.Packages.Load(defaultModelPath)
+	if config.DgraphTypes {
+		source, err := graphql.ExpandSchema(config.Sources)
 		if err != nil {
-			return errors.Wrap(err, "Could not load generated model package")
+			return errors.Wrap(err, "Could not generate Dgraph types")
 		}
-		config.DefaultModelPackage = defaultPackage
+		config.Sources = append(config.Sources, source)
 	}
 
 	return nil
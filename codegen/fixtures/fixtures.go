@@ -0,0 +1,318 @@
+// Package fixtures generates example lambda requests with fake values for all resolvers of a parsed schema.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/codegen/parser"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// maxDepth limits nested objects, deeper references only contain their id.
+const maxDepth = 2
+
+var words = []string{
+	"alpha", "amber", "breeze", "cedar", "coral", "delta", "ember", "falcon", "garnet", "harbor",
+	"island", "jasmine", "lagoon", "maple", "meadow", "nova", "orchid", "pebble", "quartz", "river",
+	"saffron", "summit", "timber", "velvet", "willow", "zephyr",
+}
+
+type Generator struct {
+	schema   *ast.Schema
+	rand     *rand.Rand
+	uid      int
+	ids      map[string]int
+	commitTs uint64
+}
+
+// NewGenerator returns a generator whose values only depend on the seed and the schema.
+func NewGenerator(schema *ast.Schema, seed int64) *Generator {
+	return &Generator{
+		schema: schema,
+		rand:   rand.New(rand.NewSource(seed)),
+		ids:    make(map[string]int),
+	}
+}
+
+// Generate returns an example request for every query, mutation, field resolver and webhook event of the tree.
+// Requests are keyed by their resolver, webhook events by $webhook.<Type>.<operation>.
+func (g *Generator) Generate(tree *parser.Tree) map[string]*api.Request {
+	requests := make(map[string]*api.Request)
+
+	var queries, mutations, fieldResolvers, webhooks []string
+	for name := range tree.ResolverTree.Queries {
+		queries = append(queries, name)
+	}
+	for name := range tree.ResolverTree.Mutations {
+		mutations = append(mutations, name)
+	}
+	for name := range tree.ResolverTree.FieldResolvers {
+		fieldResolvers = append(fieldResolvers, name)
+	}
+	for name := range tree.ResolverTree.Webhooks {
+		webhooks = append(webhooks, name)
+	}
+
+	for _, name := range sorted(queries) {
+		if field := g.rootField(g.schema.Query, name); field != nil {
+			requests["Query."+name] = g.rootRequest("Query", field)
+		}
+	}
+	for _, name := range sorted(mutations) {
+		if field := g.rootField(g.schema.Mutation, name); field != nil {
+			requests["Mutation."+name] = g.rootRequest("Mutation", field)
+		}
+	}
+
+	for _, name := range sorted(fieldResolvers) {
+		resolver := tree.ResolverTree.FieldResolvers[name]
+		parent := g.schema.Types[resolver.Parent.Name]
+		if parent == nil {
+			continue
+		}
+		field := parent.Fields.ForName(resolver.Field.Name)
+		if field == nil {
+			continue
+		}
+
+		// Dgraph calls field resolvers of interfaces with the implementing type, like the routes of the executer
+		var routes []*ast.Definition
+		if parent.Kind != ast.Interface {
+			routes = append(routes, parent)
+		}
+		for _, implementation := range resolver.Implementations {
+			if _, ok := tree.ResolverTree.FieldResolvers[implementation.Name+"."+field.Name]; ok {
+				continue
+			}
+			if def := g.schema.Types[implementation.Name]; def != nil {
+				routes = append(routes, def)
+			}
+		}
+
+		for _, def := range routes {
+			request := &api.Request{
+				Resolver: def.Name + "." + field.Name,
+				Args:     g.arguments(field.Arguments),
+				Field:    api.InfoField{Field: g.selection(field)},
+			}
+			parents := []interface{}{g.object(def, 1), g.object(def, 1)}
+			request.Parents, _ = json.Marshal(parents)
+			requests[request.Resolver] = request
+		}
+	}
+
+	for _, name := range sorted(webhooks) {
+		webhook := tree.ResolverTree.Webhooks[name]
+		for _, typeName := range append([]string{webhook.Name}, webhook.Implementations...) {
+			for _, operation := range webhook.LambdaOnMutate {
+				// Interfaces are updated and deleted by their own mutations, but only implementing types are added
+				if typeName == webhook.Name && webhook.Interface && operation == parser.ADD {
+					continue
+				}
+				requests["$webhook."+typeName+"."+string(operation)] = &api.Request{
+					Resolver: "$webhook",
+					Event:    g.event(webhook, typeName, operation),
+				}
+			}
+		}
+	}
+
+	return requests
+}
+
+func (g *Generator) rootField(def *ast.Definition, name string) *ast.FieldDefinition {
+	if def == nil {
+		return nil
+	}
+	return def.Fields.ForName(name)
+}
+
+func (g *Generator) rootRequest(typeName string, field *ast.FieldDefinition) *api.Request {
+	return &api.Request{
+		Resolver: typeName + "." + field.Name,
+		Args:     g.arguments(field.Arguments),
+		Field:    api.InfoField{Field: g.selection(field)},
+	}
+}
+
+func (g *Generator) arguments(args ast.ArgumentDefinitionList) map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage)
+	for _, arg := range args {
+		values[arg.Name], _ = json.Marshal(g.value(arg.Type, arg.Directives, arg.Name, 0))
+	}
+	return values
+}
+
+// selection returns the field info Dgraph sends, selecting all scalar fields of the returned type.
+func (g *Generator) selection(field *ast.FieldDefinition) api.SelectionField {
+	selection := api.SelectionField{Alias: field.Name, Name: field.Name, Arguments: make(map[string]json.RawMessage)}
+	def := g.schema.Types[field.Type.Name()]
+	if def == nil || (def.Kind != ast.Object && def.Kind != ast.Interface) {
+		return selection
+	}
+	for _, f := range def.Fields {
+		fieldDef := g.schema.Types[f.Type.Name()]
+		if fieldDef == nil || (fieldDef.Kind != ast.Scalar && fieldDef.Kind != ast.Enum) || f.Directives.ForName("lambda") != nil {
+			continue
+		}
+		selection.SelectionSet = append(selection.SelectionSet, api.SelectionField{Alias: f.Name, Name: f.Name, Arguments: make(map[string]json.RawMessage)})
+	}
+	return selection
+}
+
+func (g *Generator) event(webhook *parser.Webhook, typeName string, operation parser.LambdaOnMutateEvent) *api.Event {
+	g.commitTs++
+	event := &api.Event{TypeName: typeName, CommitTs: g.commitTs, Operation: string(operation)}
+	rootUID := g.nextUID()
+
+	def := g.schema.Types[typeName]
+	switch operation {
+	case parser.ADD:
+		event.Add = &api.AddEventInfo{RootUIDs: []string{rootUID}, Input: []map[string]interface{}{g.input(def, webhook.AddInput)}}
+	case parser.UPDATE:
+		event.Update = &api.UpdateEventInfo{RootUIDs: []string{rootUID}, SetPatch: g.input(def, webhook.Patch)}
+	case parser.DELETE:
+		event.Delete = &api.DeleteEventInfo{RootUIDs: []string{rootUID}}
+	}
+	return event
+}
+
+// input fills the fields of an event input. Inputs synthesized by the parser have no schema type, so the values
+// are derived from the fields of the mutated type.
+func (g *Generator) input(def *ast.Definition, input *parser.Model) map[string]interface{} {
+	values := make(map[string]interface{})
+	if input == nil {
+		return values
+	}
+	inputDef := g.schema.Types[input.Name]
+	for _, field := range input.Fields {
+		var fieldDef *ast.FieldDefinition
+		if inputDef != nil {
+			fieldDef = inputDef.Fields.ForName(field.Name)
+		}
+		if fieldDef == nil && def != nil {
+			fieldDef = def.Fields.ForName(field.Name)
+		}
+		if fieldDef == nil {
+			// The password of @secret is not a field of the type
+			values[field.Name] = g.text(field.Name, nil)
+			continue
+		}
+		values[field.Name] = g.value(fieldDef.Type, fieldDef.Directives, field.Name, 1)
+	}
+	return values
+}
+
+func (g *Generator) object(def *ast.Definition, depth int) interface{} {
+	if def.Kind == ast.Interface || def.Kind == ast.Union {
+		possible := append([]*ast.Definition{}, g.schema.GetPossibleTypes(def)...)
+		if len(possible) == 0 {
+			return nil
+		}
+		sort.Slice(possible, func(i, j int) bool { return possible[i].Name < possible[j].Name })
+		concrete := possible[g.rand.Intn(len(possible))]
+		if object, ok := g.object(concrete, depth).(map[string]interface{}); ok {
+			object["__typename"] = concrete.Name
+			return object
+		}
+		return nil
+	}
+
+	object := make(map[string]interface{})
+	if depth > maxDepth {
+		if id := def.Fields.ForName("id"); id != nil && id.Type.Name() == "ID" {
+			object["id"] = g.nextUID()
+			return object
+		}
+		return nil
+	}
+	for _, field := range def.Fields {
+		// Values of lambda and custom fields are not stored by Dgraph
+		if field.Directives.ForName("lambda") != nil || field.Directives.ForName("custom") != nil || strings.HasPrefix(field.Name, "__") {
+			continue
+		}
+		object[field.Name] = g.value(field.Type, field.Directives, field.Name, depth)
+	}
+	return object
+}
+
+func (g *Generator) value(t *ast.Type, directives ast.DirectiveList, name string, depth int) interface{} {
+	if t.Elem != nil {
+		return []interface{}{g.value(t.Elem, directives, name, depth), g.value(t.Elem, directives, name, depth)}
+	}
+
+	switch t.NamedType {
+	case "ID":
+		return g.nextUID()
+	case "Int":
+		return g.rand.Intn(100)
+	case "Int64":
+		return g.rand.Int63n(1000000)
+	case "Float":
+		return math.Round(g.rand.Float64()*10000) / 100
+	case "Boolean":
+		return g.rand.Intn(2) == 1
+	case "DateTime":
+		start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		return start.Add(time.Duration(g.rand.Intn(3*365*24)) * time.Hour).Format(time.RFC3339)
+	case "String", "Password":
+		return g.text(name, directives)
+	}
+
+	def := g.schema.Types[t.NamedType]
+	if def == nil {
+		return nil
+	}
+	switch def.Kind {
+	case ast.Enum:
+		if len(def.EnumValues) == 0 {
+			return nil
+		}
+		return def.EnumValues[g.rand.Intn(len(def.EnumValues))].Name
+	case ast.Object, ast.Interface, ast.Union, ast.InputObject:
+		return g.object(def, depth+1)
+	}
+	// Custom scalars have no known format
+	return nil
+}
+
+// text returns a string for a field. @id fields get unique values, fields searched by term or fulltext get
+// several words, so search arguments find something.
+func (g *Generator) text(name string, directives ast.DirectiveList) string {
+	if directives.ForName("id") != nil {
+		g.ids[name]++
+		return fmt.Sprintf("%s-%d", name, g.ids[name])
+	}
+	if search := directives.ForName("search"); search != nil {
+		if by := search.Arguments.ForName("by"); by != nil {
+			for _, child := range by.Value.Children {
+				if child.Value.Raw == "term" || child.Value.Raw == "fulltext" {
+					return g.word() + " " + g.word() + " " + g.word()
+				}
+			}
+		}
+		return g.word()
+	}
+	return fmt.Sprintf("%s %d", g.word(), g.rand.Intn(1000))
+}
+
+func (g *Generator) word() string {
+	return words[g.rand.Intn(len(words))]
+}
+
+func (g *Generator) nextUID() string {
+	g.uid++
+	return fmt.Sprintf("0x%x", g.uid)
+}
+
+func sorted(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
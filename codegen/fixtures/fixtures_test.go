@@ -0,0 +1,91 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/codegen/graphql"
+	"github.com/schartey/dgraph-lambda-go/codegen/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func Test_Generate(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(
+		&ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		&ast.Source{Name: "schema.graphql", Input: `
+			enum Category { Fruit, Vegetable }
+			type Product @lambdaOnMutate(add: true, delete: true) {
+				id: ID!
+				sku: String! @id
+				description: String @search(by: [term])
+				category: Category
+				price: Float
+				score: Int @lambda
+			}
+			type Query {
+				products(category: Category!, first: Int): [Product] @lambda
+			}`,
+		})
+
+	tree, err := parser.NewParser(schema, nil, nil).Parse()
+	require.NoError(t, err)
+
+	requests := NewGenerator(schema, 1).Generate(tree)
+	keys := make([]string, 0, len(requests))
+	for key := range requests {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"Query.products", "Product.score", "$webhook.Product.add", "$webhook.Product.delete"}, keys)
+
+	var category string
+	require.NoError(t, json.Unmarshal(requests["Query.products"].Args["category"], &category))
+	assert.Contains(t, []string{"Fruit", "Vegetable"}, category)
+	assert.Len(t, requests["Query.products"].Field.Field.SelectionSet, 5)
+
+	var parents []map[string]interface{}
+	require.NoError(t, json.Unmarshal(requests["Product.score"].Parents, &parents))
+	require.Len(t, parents, 2)
+	assert.NotEqual(t, parents[0]["sku"], parents[1]["sku"])
+	assert.NotContains(t, parents[0], "score")
+
+	input := requests["$webhook.Product.add"].Event.Add.Input[0]
+	assert.Regexp(t, `^\w+ \w+ \w+$`, input["description"])
+	assert.NotContains(t, input, "id")
+
+	// Interfaces get their own update and delete events besides the events of their implementations, field
+	// resolvers of interfaces are called with the implementing types
+	interfaceSchema := gqlparser.MustLoadSchema(
+		&ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		&ast.Source{Name: "schema.graphql", Input: `
+			interface Post @lambdaOnMutate(add: true, update: true) {
+				id: ID!
+				title: String
+				additionalInfo: String @lambda
+			}
+			type Question implements Post {
+				id: ID!
+				title: String
+				additionalInfo: String
+				answer: String
+			}`,
+		})
+	interfaceTree, err := parser.NewParser(interfaceSchema, nil, nil).Parse()
+	require.NoError(t, err)
+	keys = keys[:0]
+	interfaceRequests := NewGenerator(interfaceSchema, 1).Generate(interfaceTree)
+	for key := range interfaceRequests {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"$webhook.Post.update", "$webhook.Question.add", "$webhook.Question.update", "Question.additionalInfo"}, keys)
+	parents = nil
+	require.NoError(t, json.Unmarshal(interfaceRequests["Question.additionalInfo"].Parents, &parents))
+	assert.Contains(t, parents[0], "answer")
+
+	// The same seed generates the same requests
+	again, _ := json.Marshal(NewGenerator(schema, 1).Generate(tree))
+	first, _ := json.Marshal(requests)
+	assert.JSONEq(t, string(first), string(again))
+}
@@ -1,9 +1,12 @@
 package generator
 
 import (
+	"fmt"
 	"go/types"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"text/template"
 
 	"github.com/schartey/dgraph-lambda-go/codegen/config"
@@ -19,13 +22,6 @@ func generateExecuter(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rew
 	defer f.Close()
 
 	pkgs := make(map[string]*types.Package)
-	var lambdaOnMutate []string
-
-	for _, m := range parsedTree.ModelTree.Models {
-		if len(m.LambdaOnMutate) > 0 {
-			lambdaOnMutate = append(lambdaOnMutate, m.Name)
-		}
-	}
 
 	for _, m := range parsedTree.ResolverTree.FieldResolvers {
 		if m.Field.TypeName.Exported() {
@@ -34,6 +30,11 @@ func generateExecuter(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rew
 		if m.Parent.TypeName.Exported() {
 			pkgs[m.Parent.TypeName.Pkg().Name()] = m.Parent.TypeName.Pkg()
 		}
+		for _, implementation := range m.Implementations {
+			if implementation.TypeName.Exported() {
+				pkgs[implementation.TypeName.Pkg().Name()] = implementation.TypeName.Pkg()
+			}
+		}
 	}
 
 	for _, m := range parsedTree.ResolverTree.Queries {
@@ -62,10 +63,15 @@ func generateExecuter(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rew
 
 	pkgs["context"] = types.NewPackage("context", "context")
 	pkgs["errors"] = types.NewPackage("errors", "errors")
+	pkgs["fmt"] = types.NewPackage("fmt", "fmt")
 	pkgs["http"] = types.NewPackage("net/http", "http")
 	pkgs["strings"] = types.NewPackage("strings", "strings")
 	pkgs["api"] = types.NewPackage("github.com/schartey/dgraph-lambda-go/api", "api")
 
+	if len(parsedTree.ResolverTree.Webhooks) > 0 || len(interfaceParents(parsedTree.ResolverTree.FieldResolvers)) > 0 || abstractResults(parsedTree) {
+		pkgs[c.DefaultModelPackage.Name] = types.NewPackage(c.DefaultModelPackage.PkgPath, c.DefaultModelPackage.Name)
+	}
+
 	if len(parsedTree.ResolverTree.FieldResolvers) > 0 ||
 		len(parsedTree.ResolverTree.Queries) > 0 ||
 		len(parsedTree.ResolverTree.Mutations) > 0 {
@@ -76,24 +82,36 @@ func generateExecuter(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rew
 
 	err = executerTemplate.Execute(f, struct {
 		FieldResolvers      map[string]*parser.FieldResolver
+		FieldRoutes         map[string][]string
+		InterfaceParents    map[string]*parser.FieldResolver
+		Interfaces          map[string]*parser.Interface
 		Queries             map[string]*parser.Query
 		Mutations           map[string]*parser.Mutation
 		Middleware          map[string]string
 		Models              map[string]*parser.Model
-		LambdaOnMutate      []string
+		WebhookRoutes       map[string]map[parser.LambdaOnMutateEvent][]*parser.Webhook
 		Packages            map[string]*types.Package
 		PackageName         string
 		ResolverPackageName string
+		ModelPackageName    string
+		Sinks               []config.SinkConfig
+		Redactions          []string
 	}{
 		FieldResolvers:      parsedTree.ResolverTree.FieldResolvers,
+		FieldRoutes:         fieldRoutes(parsedTree.ResolverTree.FieldResolvers),
+		InterfaceParents:    interfaceParents(parsedTree.ResolverTree.FieldResolvers),
+		Interfaces:          parsedTree.ModelTree.Interfaces,
 		Queries:             parsedTree.ResolverTree.Queries,
 		Mutations:           parsedTree.ResolverTree.Mutations,
 		Middleware:          parsedTree.Middleware,
 		Models:              parsedTree.ModelTree.Models,
-		LambdaOnMutate:      lambdaOnMutate,
+		WebhookRoutes:       webhookRoutes(parsedTree.ResolverTree.Webhooks),
 		Packages:            pkgs,
 		PackageName:         c.Exec.Package,
 		ResolverPackageName: c.Resolver.Package,
+		ModelPackageName:    c.DefaultModelPackage.Name,
+		Sinks:               c.Sinks,
+		Redactions:          redactions(c.Redact, parsedTree),
 	})
 	if err != nil {
 		return err
@@ -101,13 +119,215 @@ func generateExecuter(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rew
 	return nil
 }
 
+// redactions returns the configured redaction paths and the paths of all secret arguments and fields. Arguments are
+// redacted by resolver, e.g. Mutation.createUser.input.password, parents and events by type, e.g. User.password.
+func redactions(configured []string, parsedTree *parser.Tree) []string {
+	paths := make(map[string]bool)
+	for _, path := range configured {
+		paths[path] = true
+	}
+
+	var walk func(path string, typeName string, visited map[string]bool)
+	walk = func(path string, typeName string, visited map[string]bool) {
+		var fields []*parser.Field
+		if model, ok := parsedTree.ModelTree.Models[typeName]; ok {
+			fields = model.Fields
+		} else if it, ok := parsedTree.ModelTree.Interfaces[typeName]; ok {
+			fields = it.Fields
+		}
+		if len(fields) == 0 || visited[typeName] {
+			return
+		}
+		visited[typeName] = true
+		defer delete(visited, typeName)
+
+		for _, field := range fields {
+			if field.Secret {
+				paths[path+"."+field.Name] = true
+			} else {
+				walk(path+"."+field.Name, field.TypeName.Name(), visited)
+			}
+		}
+	}
+	arguments := func(resolver string, args []*parser.Argument) {
+		for _, arg := range args {
+			path := resolver + "." + arg.Name
+			if arg.Secret {
+				paths[path] = true
+			} else {
+				walk(path, arg.TypeName.Name(), make(map[string]bool))
+			}
+		}
+	}
+
+	for _, query := range parsedTree.ResolverTree.Queries {
+		arguments("Query."+query.Name, query.Arguments)
+	}
+	for _, mutation := range parsedTree.ResolverTree.Mutations {
+		arguments("Mutation."+mutation.Name, mutation.Arguments)
+	}
+	// Parents are redacted by the type in the resolver name, so every routed type needs its own paths
+	routes := fieldRoutes(parsedTree.ResolverTree.FieldResolvers)
+	for key, fieldResolver := range parsedTree.ResolverTree.FieldResolvers {
+		for _, route := range routes[key] {
+			typeName := strings.Split(route, ".")[0]
+			walk(typeName, typeName, make(map[string]bool))
+			walk(typeName, fieldResolver.Parent.Name, make(map[string]bool))
+		}
+	}
+	for _, webhook := range parsedTree.ResolverTree.Webhooks {
+		for _, typeName := range append([]string{webhook.Name}, webhook.Implementations...) {
+			walk(typeName, webhook.AddInput.Name, make(map[string]bool))
+			walk(typeName, webhook.Patch.Name, make(map[string]bool))
+		}
+	}
+
+	var sorted []string
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// fieldRoutes returns the resolver names each field resolver is called with. Interface field resolvers are also
+// called with the names of implementing types, unless these declare the field with @lambda themselves.
+func fieldRoutes(fieldResolvers map[string]*parser.FieldResolver) map[string][]string {
+	routes := make(map[string][]string)
+	for key, fieldResolver := range fieldResolvers {
+		routes[key] = append(routes[key], key)
+		for _, implementation := range fieldResolver.Implementations {
+			resolver := implementation.Name + "." + fieldResolver.Field.Name
+			if _, ok := fieldResolvers[resolver]; !ok {
+				routes[key] = append(routes[key], resolver)
+			}
+		}
+	}
+	return routes
+}
+
+// marshal returns the statements encoding the result of a resolver. Interfaces and unions are encoded with their
+// __typename, so Dgraph can resolve the type.
+func marshal(t *parser.GoType, r *parser.TypeRef, interfaces map[string]*parser.Interface, modelPackage string) string {
+	it := interfaceOf(t, interfaces)
+	if it == nil {
+		return "response, underlyingError = json.Marshal(result)"
+	}
+	fail := "return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}"
+	return fmt.Sprintf(`var raw %s
+%s
+response, underlyingError = json.Marshal(raw)`, rawRef(r), encode("raw", "result", modelPackage+".Marshal"+it.Name, "underlyingError", fail, r, 0))
+}
+
+// abstractResults reports whether a resolver returns interfaces or unions, which are encoded by the model package.
+func abstractResults(parsedTree *parser.Tree) bool {
+	for _, fieldResolver := range parsedTree.ResolverTree.FieldResolvers {
+		if fieldResolver.Field.Interface {
+			return true
+		}
+	}
+	for _, query := range parsedTree.ResolverTree.Queries {
+		if query.Return.Interface {
+			return true
+		}
+	}
+	for _, mutation := range parsedTree.ResolverTree.Mutations {
+		if mutation.Return.Interface {
+			return true
+		}
+	}
+	return false
+}
+
+// list returns the type of the results of a field resolver.
+func list(r *parser.TypeRef) *parser.TypeRef {
+	return &parser.TypeRef{NonNull: true, Elem: r}
+}
+
+// interfaceParents returns a field resolver of every interface with field resolvers by interface name, to generate
+// the decoding of its parents.
+func interfaceParents(fieldResolvers map[string]*parser.FieldResolver) map[string]*parser.FieldResolver {
+	parents := make(map[string]*parser.FieldResolver)
+	for _, fieldResolver := range fieldResolvers {
+		if fieldResolver.Parent.Interface {
+			parents[fieldResolver.Parent.Name] = fieldResolver
+		}
+	}
+	return parents
+}
+
+// webhookRoutes maps the type name and operation of an event to the webhooks handling it.
+// The webhook of the type itself is called before the webhooks of its interfaces.
+func webhookRoutes(webhooks map[string]*parser.Webhook) map[string]map[parser.LambdaOnMutateEvent][]*parser.Webhook {
+	routes := make(map[string]map[parser.LambdaOnMutateEvent][]*parser.Webhook)
+
+	var names []string
+	for name := range webhooks {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if webhooks[names[i]].Interface != webhooks[names[j]].Interface {
+			return !webhooks[names[i]].Interface
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		webhook := webhooks[name]
+		for _, typeName := range append([]string{webhook.Name}, webhook.Implementations...) {
+			if routes[typeName] == nil {
+				routes[typeName] = make(map[parser.LambdaOnMutateEvent][]*parser.Webhook)
+			}
+			for _, event := range webhook.LambdaOnMutate {
+				routes[typeName][event] = append(routes[typeName][event], webhook)
+			}
+		}
+	}
+	return routes
+}
+
+func sink(s config.SinkConfig) string {
+	var headers []string
+	for key, value := range s.Headers {
+		headers = append(headers, fmt.Sprintf("%q: %q", key, value))
+	}
+	sort.Strings(headers)
+
+	switch s.Kind {
+	case "http":
+		return fmt.Sprintf("api.NewHTTPSink(%q, map[string]string{%s}, api.JSONEncoder)", s.URL, strings.Join(headers, ", "))
+	case "cloudevents":
+		source := s.Source
+		if source == "" {
+			source = "dgraph-lambda-go"
+		}
+		return fmt.Sprintf("api.NewHTTPSink(%q, map[string]string{%s}, api.CloudEventsEncoder(%q, \"dgraph\"))", s.URL, strings.Join(headers, ", "), source)
+	default:
+		return fmt.Sprintf("api.NewFileSink(%q, api.JSONEncoder)", s.Filename)
+	}
+}
+
+func quoteAll(values []string) string {
+	var quoted []string
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
 var executerTemplate = template.Must(template.New("executer").Funcs(template.FuncMap{
-	"path":     pkgPath,
-	"typeName": typeName,
-	"ref":      resolverRef,
-	"untitle":  untitle,
-	"args":     args,
-	"pointer":  pointer,
+	"path":      pkgPath,
+	"typeName":  typeName,
+	"ref":       resolverRef,
+	"untitle":   untitle,
+	"args":      args,
+	"pointer":   pointer,
+	"object":    object,
+	"sink":      sink,
+	"quoteAll":  quoteAll,
+	"marshal":   marshal,
+	"list":      list,
+	"eventType": eventType,
 }).Parse(`
 package {{.PackageName}}
 
@@ -123,15 +343,58 @@ type Executer struct {
 	mutationResolver 	{{.ResolverPackageName}}.MutationResolver
 	middlewareResolver 	{{.ResolverPackageName}}.MiddlewareResolver
 	webhookResolver 	{{.ResolverPackageName}}.WebhookResolver
+	sinks 				*api.SinkRouter
+	eventBus 			*api.EventBus
+	logger 				api.Logger
 }
 
 func NewExecuter(resolver *{{.ResolverPackageName}}.Resolver) api.ExecuterInterface {
-	return Executer{fieldResolver: {{.ResolverPackageName}}.FieldResolver{Resolver: resolver}, queryResolver: {{.ResolverPackageName}}.QueryResolver{Resolver: resolver}, mutationResolver: {{.ResolverPackageName}}.MutationResolver{Resolver: resolver}, middlewareResolver: {{.ResolverPackageName}}.MiddlewareResolver{Resolver: resolver}, webhookResolver: {{.ResolverPackageName}}.WebhookResolver{Resolver: resolver}}
+	return NewExecuterWithOptions(resolver, api.ExecuterOptions{})
+}
+
+func NewExecuterWithEventBus(resolver *{{.ResolverPackageName}}.Resolver, eventBus *api.EventBus) api.ExecuterInterface {
+	return NewExecuterWithOptions(resolver, api.ExecuterOptions{EventBus: eventBus})
+}
+
+func NewExecuterWithOptions(resolver *{{.ResolverPackageName}}.Resolver, options api.ExecuterOptions) api.ExecuterInterface {
+	if options.Sinks == nil {
+		options.Sinks = newSinkRouter()
+	}
+	if options.EventBus == nil {
+		options.EventBus = api.DefaultEventBus
+	}
+	if options.Logger == nil {
+		options.Logger = api.DefaultLogger
+	}
+	return Executer{fieldResolver: {{.ResolverPackageName}}.FieldResolver{Resolver: resolver}, queryResolver: {{.ResolverPackageName}}.QueryResolver{Resolver: resolver}, mutationResolver: {{.ResolverPackageName}}.MutationResolver{Resolver: resolver}, middlewareResolver: {{.ResolverPackageName}}.MiddlewareResolver{Resolver: resolver}, webhookResolver: {{.ResolverPackageName}}.WebhookResolver{Resolver: resolver}, sinks: options.Sinks, eventBus: options.EventBus, logger: options.Logger}
+}
+
+func newSinkRouter() *api.SinkRouter {
+	router := api.NewSinkRouter()
+	{{- range $sink := .Sinks }}
+	router.Add("{{ $sink.Type }}", []string{ {{- quoteAll $sink.Operations -}} }, {{ sink $sink }})
+	{{- end }}
+	return router
+}
+
+func (e Executer) Redactions() []string {
+	return []string{ {{- quoteAll .Redactions -}} }
 }
 
 func (e Executer) Resolve(ctx context.Context, request *api.Request) (response []byte, err *api.LambdaError) {
 	if request.Resolver == "$webhook" {
-		return nil, e.resolveWebhook(ctx, request)
+		if err = e.resolveWebhook(ctx, request); err != nil {
+			return nil, err
+		}
+		// Subscribers and sinks only receive handled events of enabled operations. They must not fail the webhook,
+		// or its retry would run the resolvers and all of them again.
+		if underlyingError := e.eventBus.Publish(ctx, request.Event); underlyingError != nil {
+			e.logger.Printf("Could not publish %s.%s to subscribers: %s", request.Event.TypeName, request.Event.Operation, underlyingError.Error())
+		}
+		if underlyingError := e.sinks.Publish(ctx, request.Event); underlyingError != nil {
+			e.logger.Printf("Could not publish %s.%s to sinks: %s", request.Event.TypeName, request.Event.Operation, underlyingError.Error())
+		}
+		return nil, nil
 	} else {
 		parentsBytes, underlyingError := request.Parents.MarshalJSON()
 		if underlyingError != nil {
@@ -157,8 +420,8 @@ func (e Executer) Resolve(ctx context.Context, request *api.Request) (response [
 
 func (e Executer) middleware(mc *api.MiddlewareContext) (err *api.LambdaError) {
 	switch mc.Request.Resolver {
-		{{- range $fieldResolver := .FieldResolvers}}{{ if ne (len $fieldResolver.Middleware) 0 }}
-		case "{{$fieldResolver.Parent.Name }}.{{$fieldResolver.Field.Name}}":
+		{{- range $key, $fieldResolver := .FieldResolvers}}{{ if ne (len $fieldResolver.Middleware) 0 }}
+		case {{ quoteAll (index $.FieldRoutes $key) }}:
 			{
 				{{- range $middleware := $fieldResolver.Middleware}}
 				if err = e.middlewareResolver.Middleware_{{$middleware}}(mc); err != nil {
@@ -197,11 +460,18 @@ func (e Executer) middleware(mc *api.MiddlewareContext) (err *api.LambdaError) {
 
 func (e Executer) resolveField(ctx context.Context, request *api.Request, parentsBytes []byte) (response []byte, err *api.LambdaError) {
 	switch request.Resolver {
-		{{- range $fieldResolver := .FieldResolvers}}
-		case "{{$fieldResolver.Parent.Name }}.{{$fieldResolver.Field.Name}}":
+		{{- range $key, $fieldResolver := .FieldResolvers}}
+		case {{ quoteAll (index $.FieldRoutes $key) }}:
 			{
-				var parents []{{ pointer $fieldResolver.Parent.GoType false }}
+				{{- if $fieldResolver.Parent.Interface }}
+				parents, err := decode{{$fieldResolver.Parent.Name}}Parents(request.Resolver, parentsBytes)
+				if err != nil {
+					return nil, err
+				}
+				{{- else }}
+				var parents []{{ object $fieldResolver.Parent.GoType }}
 				json.Unmarshal(parentsBytes, &parents)
+				{{- end }}
 
 				result, err := e.fieldResolver.{{$fieldResolver.Parent.Name }}_{{$fieldResolver.Field.Name}}(ctx, parents, request.AuthHeader)
 				if err != nil {
@@ -209,7 +479,7 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 				}
 
 				var underlyingError error
-				response, underlyingError = json.Marshal(result)
+				{{ marshal $fieldResolver.Field.GoType (list $fieldResolver.Field.Type) $.Interfaces $.ModelPackageName }}
 				if underlyingError != nil {
 					return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}
 				} else {
@@ -223,13 +493,36 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 	return nil, &api.LambdaError{Underlying: errors.New("could not find query resolver"), Status: http.StatusNotFound}
 }
 
+{{- range $name, $fieldResolver := .InterfaceParents }}
+
+// decode{{$name}}Parents decodes parents into the types implementing {{$name}}. The type is taken from the resolver
+// name, or from the __typename or dgraph.type of each parent if the resolver is called with {{$name}}.
+func decode{{$name}}Parents(resolver string, parentsBytes []byte) ([]{{ object $fieldResolver.Parent.GoType }}, *api.LambdaError) {
+	var rawParents []json.RawMessage
+	if underlyingError := json.Unmarshal(parentsBytes, &rawParents); underlyingError != nil {
+		return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+	}
+
+	resolverTypeName := strings.Split(resolver, ".")[0]
+	parents := make([]{{ object $fieldResolver.Parent.GoType }}, len(rawParents))
+	for i, rawParent := range rawParents {
+		parent, underlyingError := {{$.ModelPackageName}}.Unmarshal{{$name}}(rawParent, resolverTypeName)
+		if underlyingError != nil {
+			return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+		}
+		parents[i] = parent
+	}
+	return parents, nil
+}
+{{- end }}
+
 func (e Executer) resolveQuery(ctx context.Context, request *api.Request) (response []byte, err *api.LambdaError) {
 	switch request.Resolver {
 	{{- range $query := .Queries}}
          case "Query.{{$query.Name}}":
 	{
 		{{- range $arg := $query.Arguments }}
-		var {{ $arg.Name }} {{ pointer $arg.GoType $arg.IsArray }} 
+		var {{ $arg.Name }} {{ pointer $arg.GoType $arg.Type }} 
 		json.Unmarshal(request.Args["{{$arg.Name}}"], &{{$arg.Name}})
 		{{- end }}	
 		result, err := e.queryResolver.Query_{{$query.Name}}(ctx{{ if ne (len $query.Arguments) 0}}, {{$query.Arguments | args}}{{end}}, request.AuthHeader)
@@ -238,7 +531,7 @@ func (e Executer) resolveQuery(ctx context.Context, request *api.Request) (respo
 		}
 
 		var underlyingError error
-		response, underlyingError = json.Marshal(result)
+		{{ marshal $query.Return.GoType $query.Return.Type $.Interfaces $.ModelPackageName }}
 		if underlyingError != nil {
 			return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}
 		} else {
@@ -258,7 +551,7 @@ func (e Executer) resolveMutation(ctx context.Context, request *api.Request) (re
 		case "Mutation.{{$mutation.Name}}":
 			{
 				{{- range $arg := $mutation.Arguments }}
-				var {{ $arg.Name }} {{ pointer $arg.GoType $arg.IsArray }} 
+				var {{ $arg.Name }} {{ pointer $arg.GoType $arg.Type }} 
 				json.Unmarshal(request.Args["{{$arg.Name}}"], &{{$arg.Name}})
 				{{- end }}	
 				result, err := e.mutationResolver.Mutation_{{$mutation.Name}}(ctx{{ if ne (len $mutation.Arguments) 0}}, {{$mutation.Arguments | args}}{{ end }}, request.AuthHeader)
@@ -267,7 +560,7 @@ func (e Executer) resolveMutation(ctx context.Context, request *api.Request) (re
 				}
 
 				var underlyingError error
-				response, underlyingError = json.Marshal(result)
+				{{ marshal $mutation.Return.GoType $mutation.Return.Type $.Interfaces $.ModelPackageName }}
 				if underlyingError != nil {
 					return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}
 				} else {
@@ -283,10 +576,29 @@ func (e Executer) resolveMutation(ctx context.Context, request *api.Request) (re
 
 func (e Executer) resolveWebhook(ctx context.Context, request *api.Request) (err *api.LambdaError) {
 	switch request.Event.TypeName {
-		{{- range $name := .LambdaOnMutate}}
-	case "{{$name}}":
-		err = e.webhookResolver.Webhook_{{$name}}(ctx, request.Event)
-		return err
+		{{- range $typeName, $operations := .WebhookRoutes}}
+	case "{{$typeName}}":
+		switch request.Event.Operation {
+			{{- range $operation, $webhooks := $operations }}
+		case "{{$operation}}":
+			{{- range $webhook := $webhooks }}
+			{
+				var event *{{$.ModelPackageName}}.{{eventType $webhook.Name $operation}}
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_{{$webhook.Name}}_{{$operation}}") {
+					if err = e.webhookResolver.Webhook_{{$webhook.Name}}_{{$operation}}(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_{{$webhook.Name}}_{{$operation}}")
+				}
+			}
+			{{- end }}
+			return nil
+			{{- end }}
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for {{$typeName}}"), Status: http.StatusBadRequest}
 		{{- end }}
 	}
 	
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/generated"
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/resolvers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The generated executer of the example schema, Hotel only enables add and delete webhooks
+func hotelEvent(operation string) *api.Request {
+	event := &api.Event{TypeName: "Hotel", Operation: operation, CommitTs: 1}
+	switch operation {
+	case "add":
+		event.Add = &api.AddEventInfo{RootUIDs: []string{"0x1"}, Input: []map[string]interface{}{{"name": "x"}}}
+	case "update":
+		event.Update = &api.UpdateEventInfo{RootUIDs: []string{"0x1"}}
+	}
+	return &api.Request{Resolver: "$webhook", Event: event}
+}
+
+type recordingSink struct {
+	events []*api.Event
+}
+
+func (s *recordingSink) Publish(ctx context.Context, event *api.Event) error {
+	s.events = append(s.events, event)
+	return errors.New("unavailable")
+}
+
+func Test_Executer_Webhook_Sinks(t *testing.T) {
+	sink := &recordingSink{}
+	sinks := api.NewSinkRouter()
+	sinks.Add("", nil, sink)
+	executer := generated.NewExecuterWithOptions(&resolvers.Resolver{}, api.ExecuterOptions{Sinks: sinks, EventBus: api.NewEventBus()})
+
+	// Sinks only receive handled events and do not fail the webhook
+	_, err := executer.Resolve(context.Background(), hotelEvent("update"))
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, int(err.Status))
+	assert.Empty(t, sink.events)
+
+	_, err = executer.Resolve(context.Background(), hotelEvent("add"))
+	assert.Nil(t, err)
+	assert.Len(t, sink.events, 1)
+}
+
+func Test_Executer_Webhook_EventBus(t *testing.T) {
+	bus := api.NewEventBus()
+	var operations []string
+	bus.Subscribe("Hotel", nil, func(ctx context.Context, event *api.Event) error {
+		operations = append(operations, event.Operation)
+		return errors.New("failed")
+	})
+	executer := generated.NewExecuterWithOptions(&resolvers.Resolver{}, api.ExecuterOptions{Sinks: api.NewSinkRouter(), EventBus: bus})
+
+	// Operations without webhook are not published, failing subscribers do not fail the webhook
+	_, err := executer.Resolve(context.Background(), hotelEvent("update"))
+	require.NotNil(t, err)
+	_, err = executer.Resolve(context.Background(), hotelEvent("add"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"add"}, operations)
+}
+
+func fieldRequest(typeName string, field string, parent string) *api.Request {
+	return &api.Request{Resolver: typeName + "." + field, Parents: []byte("[" + parent + "]")}
+}
+
+func Test_Executer_Interface_Field(t *testing.T) {
+	executer := generated.NewExecuter(&resolvers.Resolver{})
+
+	// Implementing types are routed to the field resolver of the interface
+	_, err := executer.Resolve(context.Background(), fieldRequest("Question", "additionalInfo", `{"id":"0x1"}`))
+	assert.Nil(t, err)
+
+	_, err = executer.Resolve(context.Background(), fieldRequest("Post", "additionalInfo", `{"__typename":"Comment","id":"0x2"}`))
+	assert.Nil(t, err)
+
+	_, err = executer.Resolve(context.Background(), fieldRequest("Post", "additionalInfo", `{"id":"0x3"}`))
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, int(err.Status))
+}
@@ -71,6 +71,7 @@ var fieldResolverTemplate = template.Must(template.New("field-resolver").Funcs(t
 	"ref":     modelRef,
 	"path":    pkgPath,
 	"pointer": pointer,
+	"object":  object,
 	"body":    fieldResolverBody,
 	"is":      is,
 }).Parse(`
@@ -83,7 +84,7 @@ import(
 
 type FieldResolverInterface interface {
 {{- range $fieldResolver := .FieldResolvers}}
-	{{$fieldResolver.Parent.Name }}_{{$fieldResolver.Field.Name}}(ctx context.Context, parents []{{ pointer $fieldResolver.Parent.GoType false }}, authHeader api.AuthHeader) ([]{{ pointer $fieldResolver.Field.GoType $fieldResolver.Field.IsArray }}, *api.LambdaError){{ end }}
+	{{$fieldResolver.Parent.Name }}_{{$fieldResolver.Field.Name}}(ctx context.Context, parents []{{ object $fieldResolver.Parent.GoType }}, authHeader api.AuthHeader) ([]{{ pointer $fieldResolver.Field.GoType $fieldResolver.Field.Type }}, *api.LambdaError){{ end }}
 }
 
 type FieldResolver struct {
@@ -91,11 +92,11 @@ type FieldResolver struct {
 }
 
 {{- range $fieldResolver := .FieldResolvers}}
-func (f *FieldResolver) {{$fieldResolver.Parent.Name }}_{{$fieldResolver.Field.Name}}(ctx context.Context, parents []{{ pointer $fieldResolver.Parent.GoType false }}, authHeader api.AuthHeader) ([]{{ pointer $fieldResolver.Field.GoType $fieldResolver.Field.IsArray }}, *api.LambdaError) { {{ body (printf "%s_%s" $fieldResolver.Parent.Name $fieldResolver.Field.Name) $.Rewriter }}}
+func (f *FieldResolver) {{$fieldResolver.Parent.Name }}_{{$fieldResolver.Field.Name}}(ctx context.Context, parents []{{ object $fieldResolver.Parent.GoType }}, authHeader api.AuthHeader) ([]{{ pointer $fieldResolver.Field.GoType $fieldResolver.Field.Type }}, *api.LambdaError) { {{ body (printf "%s_%s" $fieldResolver.Parent.Name $fieldResolver.Field.Name) $.Rewriter }}}
 {{ end }}
 
 {{- range $key, $depBody := .Rewriter.DeprecatedBodies }}
-{{ if and (not (is $key "Query_")) (not (is $key "Mutation_")) (not (is $key "Middleware_")) }}
+{{ if and (not (is $key "Query_")) (not (is $key "Mutation_")) (not (is $key "Middleware_")) (not (is $key "Webhook_")) }}
 /* {{ $depBody }} */
 {{ end }}
 {{ end }}
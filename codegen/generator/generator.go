@@ -61,6 +61,11 @@ func title(t string) string {
 	return strings.Title(t)
 }
 
+// eventType returns the name of the generated event of a webhook operation, e.g. UserAddEvent.
+func eventType(name string, event parser.LambdaOnMutateEvent) string {
+	return name + title(string(event)) + "Event"
+}
+
 func untitle(s string) string {
 	if len(s) == 0 {
 		return s
@@ -75,16 +80,29 @@ func typeName(t *types.TypeName) string {
 	return t.Name()
 }
 
-func pointer(t *parser.GoType, isArray bool) string {
-	if !t.TypeName.Exported() {
-		return t.TypeName.Name()
-	} else {
-		if isArray {
-			return fmt.Sprintf("[]*%s", resolverRef(t))
-		} else {
-			return fmt.Sprintf("*%s", resolverRef(t))
-		}
+// ref applies the modifiers of a type reference to a type name. Non-null scalars and enums are values, nullable
+// ones are pointers, so an absent value can be told apart from the zero value. Objects are always referenced by
+// pointer, interfaces never.
+func ref(name string, t *parser.GoType, r *parser.TypeRef) string {
+	if r.IsArray() {
+		return "[]" + ref(name, t, r.Elem)
+	}
+	if t.Interface {
+		return name
 	}
+	if t.Object || !r.NonNull {
+		return "*" + name
+	}
+	return name
+}
+
+func pointer(t *parser.GoType, r *parser.TypeRef) string {
+	return ref(resolverRef(t), t, r)
+}
+
+// object references a parent or event input.
+func object(t *parser.GoType) string {
+	return pointer(t, &parser.TypeRef{})
 }
 
 func args(args []*parser.Argument) string {
@@ -100,28 +118,28 @@ func argsW(args []*parser.Argument) string {
 	var arglist []string
 
 	for _, arg := range args {
-		arglist = append(arglist, fmt.Sprintf("%s %s", arg.Name, pointer(arg.GoType, arg.IsArray)))
+		arglist = append(arglist, fmt.Sprintf("%s %s", arg.Name, pointer(arg.GoType, arg.Type)))
 	}
 	return strings.Join(arglist, ",")
 }
 
-func returnValue(t *parser.GoType, isArray bool) string {
+func returnValue(t *parser.GoType, r *parser.TypeRef) string {
 	defaultValue, err := graphql.GetDefaultStringValueForType(t.TypeName.Name())
 	fmt.Println(t.TypeName.Name())
-	if err != nil || isArray {
+	if err != nil || r.IsArray() || !r.NonNull {
 		return "nil"
 	} else {
 		return defaultValue
 	}
 }
 
-func body(t *parser.GoType, isArray bool, key string, rewriter *rewriter.Rewriter) string {
+func body(t *parser.GoType, r *parser.TypeRef, key string, rewriter *rewriter.Rewriter) string {
 	if val, ok := rewriter.RewriteBodies[key]; ok {
 		return val
 	} else {
 		return fmt.Sprintf(`
 	return %s, nil
-`, returnValue(t, isArray))
+`, returnValue(t, r))
 	}
 }
 
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/codegen/parser"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
+)
+
+func Test_Ref(t *testing.T) {
+	float := &parser.GoType{TypeName: types.NewTypeName(0, nil, "float64", nil)}
+	hotel := &parser.GoType{TypeName: types.NewTypeName(0, nil, "Hotel", nil), Object: true}
+
+	assert.Equal(t, "float64", pointer(float, &parser.TypeRef{NonNull: true}))
+	assert.Equal(t, "*float64", pointer(float, &parser.TypeRef{}))
+	assert.Equal(t, "[]float64", pointer(float, &parser.TypeRef{Elem: &parser.TypeRef{NonNull: true}}))
+	assert.Equal(t, "[][]*float64", pointer(float, &parser.TypeRef{NonNull: true, Elem: &parser.TypeRef{Elem: &parser.TypeRef{}}}))
+	assert.Equal(t, "*Hotel", pointer(hotel, &parser.TypeRef{NonNull: true}))
+	assert.Equal(t, "[][]*Hotel", pointer(hotel, &parser.TypeRef{Elem: &parser.TypeRef{Elem: &parser.TypeRef{NonNull: true}}}))
+}
+
+func Test_FieldRoutes(t *testing.T) {
+	score := &parser.Field{Name: "score"}
+	fieldResolvers := map[string]*parser.FieldResolver{
+		"Ranked.score": {Field: score, Parent: &parser.Parent{Name: "Ranked"}, Implementations: []*parser.Parent{{Name: "Team"}, {Name: "User"}}},
+		"User.score":   {Field: score, Parent: &parser.Parent{Name: "User"}},
+	}
+
+	routes := fieldRoutes(fieldResolvers)
+	assert.Equal(t, []string{"Ranked.score", "Team.score"}, routes["Ranked.score"])
+	assert.Equal(t, []string{"User.score"}, routes["User.score"])
+}
+
+func Test_Redactions(t *testing.T) {
+	secret := &parser.Field{Name: "token", Secret: true}
+	tree := &parser.Tree{
+		ModelTree: &parser.ModelTree{
+			Interfaces: map[string]*parser.Interface{"Post": {Name: "Post", Fields: []*parser.Field{secret}}},
+			Models:     map[string]*parser.Model{"Comment": {Name: "Comment", Fields: []*parser.Field{secret, {Name: "pin", Secret: true}}}},
+		},
+		ResolverTree: &parser.ResolverTree{
+			FieldResolvers: map[string]*parser.FieldResolver{
+				"Post.info": {Field: &parser.Field{Name: "info"}, Parent: &parser.Parent{Name: "Post"}, Implementations: []*parser.Parent{{Name: "Comment"}}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"Comment.pin", "Comment.token", "Post.token"}, redactions(nil, tree))
+}
+
+func Test_Methods(t *testing.T) {
+	defaultPackage = &packages.Package{PkgPath: "example.com/model"}
+	pkg := types.NewPackage("example.com/model", "model")
+	str := &parser.GoType{TypeName: types.NewTypeName(0, nil, "string", nil)}
+	labeled := &parser.GoType{TypeName: types.NewTypeName(0, pkg, "Labeled", nil), Object: true, Interface: true}
+	box := &parser.GoType{TypeName: types.NewTypeName(0, pkg, "Box", nil), Object: true}
+
+	interfaces := map[string]*parser.Interface{
+		"Labeled": {Name: "Labeled", GoType: labeled, Fields: []*parser.Field{
+			{Name: "id", GoType: str, Type: &parser.TypeRef{NonNull: true}},
+			{Name: "label", GoType: str, Type: &parser.TypeRef{}},
+			{Name: "related", GoType: labeled, Type: &parser.TypeRef{Elem: &parser.TypeRef{}}},
+		}},
+		"Named": {Name: "Named", Fields: []*parser.Field{
+			{Name: "id", GoType: str, Type: &parser.TypeRef{NonNull: true}},
+		}},
+	}
+	model := &parser.Model{Name: "Box", GoType: box, Implements: []*parser.GoType{labeled, {TypeName: types.NewTypeName(0, pkg, "Named", nil)}}, Fields: []*parser.Field{
+		{Name: "id", GoType: str, Type: &parser.TypeRef{NonNull: true}},
+		{Name: "label", GoType: str, Type: &parser.TypeRef{NonNull: true}},
+		{Name: "related", GoType: box, Type: &parser.TypeRef{Elem: &parser.TypeRef{NonNull: true}}},
+	}}
+
+	var names []string
+	for _, method := range methods(model, interfaces) {
+		names = append(names, method.Name)
+	}
+	assert.Equal(t, []string{"IsLabeled", "GetId", "GetLabel", "GetRelated", "IsNamed"}, names)
+
+	assert.Equal(t, "m.Id", convert("m.Id", str, &parser.TypeRef{NonNull: true}, str, &parser.TypeRef{NonNull: true}))
+	assert.Equal(t, "&m.Label", convert("m.Label", str, &parser.TypeRef{NonNull: true}, str, &parser.TypeRef{}))
+	assert.Contains(t, convert("m.Related", box, &parser.TypeRef{Elem: &parser.TypeRef{NonNull: true}}, labeled, &parser.TypeRef{Elem: &parser.TypeRef{}}), "func(values []*Box) []Labeled {")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/types"
 	"os"
+	"strings"
 	"text/template"
 
 	"github.com/schartey/dgraph-lambda-go/codegen/config"
@@ -29,6 +30,15 @@ func generateModel(c *config.Config, parsedTree *parser.Tree) error {
 	var interfaces = make(map[string]*parser.Interface)
 	var scalars = make(map[string]*parser.Scalar)
 
+	for _, w := range parsedTree.ResolverTree.Webhooks {
+		for _, input := range []*parser.Model{w.AddInput, w.Patch} {
+			// Synthesized inputs that were not bound to a package are generated into the model package
+			if input.TypeName.Pkg() != nil && input.TypeName.Pkg().Path() != c.DefaultModelPackage.PkgPath {
+				pkgs[input.TypeName.Pkg().Name()] = input.TypeName.Pkg()
+			}
+		}
+	}
+
 	for _, m := range parsedTree.ModelTree.Models {
 		if m.GoType.TypeName.Pkg().Path() == c.DefaultModelPackage.PkgPath && !m.GoType.Autobind {
 			models[m.Name] = m
@@ -54,6 +64,17 @@ func generateModel(c *config.Config, parsedTree *parser.Tree) error {
 			scalars[m.Name] = m
 		}
 	}
+	for _, it := range parsedTree.ModelTree.Interfaces {
+		for _, t := range append([]*parser.Parent{{GoType: it.GoType}}, it.Types...) {
+			if t.TypeName.Exported() && t.TypeName.Pkg().Path() != c.DefaultModelPackage.PkgPath {
+				pkgs[t.TypeName.Pkg().Name()] = t.TypeName.Pkg()
+			}
+		}
+	}
+	if len(parsedTree.ModelTree.Interfaces) > 0 {
+		pkgs["fmt"] = types.NewPackage("fmt", "fmt")
+		pkgs["json"] = types.NewPackage("encoding/json", "json")
+	}
 	if len(enums) > 0 {
 		pkgs["fmt"] = types.NewPackage("fmt", "fmt")
 		pkgs["strconv"] = types.NewPackage("strconv", "strconv")
@@ -61,19 +82,23 @@ func generateModel(c *config.Config, parsedTree *parser.Tree) error {
 	}
 
 	err = modelTemplate.Execute(f, struct {
-		Interfaces  map[string]*parser.Interface
-		Enums       map[string]*parser.Enum
-		Scalars     map[string]*parser.Scalar
-		Models      map[string]*parser.Model
-		Packages    map[string]*types.Package
-		PackageName string
+		Interfaces    map[string]*parser.Interface
+		AllInterfaces map[string]*parser.Interface
+		Enums         map[string]*parser.Enum
+		Scalars       map[string]*parser.Scalar
+		Models        map[string]*parser.Model
+		Webhooks      map[string]*parser.Webhook
+		Packages      map[string]*types.Package
+		PackageName   string
 	}{
-		Interfaces:  interfaces,
-		Enums:       enums,
-		Scalars:     parsedTree.ModelTree.Scalars,
-		Models:      models,
-		Packages:    pkgs,
-		PackageName: c.Model.Package,
+		Interfaces:    interfaces,
+		AllInterfaces: parsedTree.ModelTree.Interfaces,
+		Enums:         enums,
+		Scalars:       parsedTree.ModelTree.Scalars,
+		Models:        models,
+		Webhooks:      parsedTree.ResolverTree.Webhooks,
+		Packages:      pkgs,
+		PackageName:   c.Model.Package,
 	})
 	if err != nil {
 		return err
@@ -81,32 +106,189 @@ func generateModel(c *config.Config, parsedTree *parser.Tree) error {
 	return nil
 }
 
-func modelRef(t *parser.GoType, isArray bool) string {
+func modelRef(t *parser.GoType, r *parser.TypeRef) string {
 	if t.TypeName.Exported() && t.TypeName.Pkg().Path() != defaultPackage.PkgPath {
-		if isArray {
-			return fmt.Sprintf("[]*%s.%s", t.TypeName.Pkg().Name(), t.TypeName.Name())
-		} else {
-			return fmt.Sprintf("*%s.%s", t.TypeName.Pkg().Name(), t.TypeName.Name())
+		return ref(fmt.Sprintf("%s.%s", t.TypeName.Pkg().Name(), t.TypeName.Name()), t, r)
+	}
+	return ref(t.TypeName.Name(), t, r)
+}
+
+type method struct {
+	Name   string
+	Type   string
+	Return string
+}
+
+// getters returns a getter for every field of an interface.
+func getters(it *parser.Interface) []*method {
+	var result []*method
+	for _, field := range it.Fields {
+		result = append(result, &method{Name: "Get" + title(field.Name), Type: modelRef(field.GoType, field.Type)})
+	}
+	return result
+}
+
+// methods returns the marker and getter methods a model needs to implement its interfaces. Getters of fields shared
+// by several interfaces are only generated once.
+func methods(model *parser.Model, interfaces map[string]*parser.Interface) []*method {
+	var result []*method
+	generated := make(map[string]bool)
+	for _, implements := range model.Implements {
+		name := implements.TypeName.Name()
+		result = append(result, &method{Name: "Is" + name})
+
+		it, ok := interfaces[name]
+		if !ok {
+			continue
+		}
+		for _, field := range it.Fields {
+			getter := "Get" + title(field.Name)
+			if generated[getter] {
+				continue
+			}
+			for _, modelField := range model.Fields {
+				if modelField.Name == field.Name {
+					generated[getter] = true
+					result = append(result, &method{
+						Name:   getter,
+						Type:   modelRef(field.GoType, field.Type),
+						Return: convert("m."+title(modelField.Name), modelField.GoType, modelField.Type, field.GoType, field.Type),
+					})
+				}
+			}
 		}
 	}
-	if t.TypeName.Exported() {
-		if isArray {
-			return fmt.Sprintf("[]*%s", t.TypeName.Name())
-		} else {
-			return fmt.Sprintf("*%s", t.TypeName.Name())
+	return result
+}
+
+// convert returns an expression converting the field of an implementing type to the type of the interface field.
+// Implementing types can declare non-null fields where the interface allows null, and lists of implementing types.
+func convert(expr string, from *parser.GoType, fromRef *parser.TypeRef, to *parser.GoType, toRef *parser.TypeRef) string {
+	fromType, toType := modelRef(from, fromRef), modelRef(to, toRef)
+	if fromType == toType {
+		return expr
+	}
+	if fromRef.IsArray() && toRef.IsArray() {
+		return fmt.Sprintf(`func(values %s) %s {
+	converted := make(%s, len(values))
+	for i := range values {
+		converted[i] = %s
+	}
+	return converted
+}(%s)`, fromType, toType, toType, convert("values[i]", from, fromRef.Elem, to, toRef.Elem), expr)
+	}
+	if "*"+fromType == toType {
+		return "&" + expr
+	}
+	// Objects are assignable to the interfaces they implement
+	return expr
+}
+
+type interfaceField struct {
+	Name   string
+	Tag    string
+	Raw    string
+	Decode string
+	Encode string
+}
+
+// interfaceFields returns the fields of a model holding interfaces or unions, which are decoded from and encoded to
+// raw JSON by the helpers of the interface.
+func interfaceFields(model *parser.Model, interfaces map[string]*parser.Interface) []*interfaceField {
+	var result []*interfaceField
+	for _, field := range model.Fields {
+		it := interfaceOf(field.GoType, interfaces)
+		if it == nil {
+			continue
 		}
+		result = append(result, &interfaceField{
+			Name:   title(field.Name),
+			Tag:    `json:"` + field.Name + `"`,
+			Raw:    rawRef(field.Type),
+			Decode: decode("m."+title(field.Name), "raw."+title(field.Name), it, field.GoType, field.Type, 0),
+			Encode: encode("raw."+title(field.Name), "m."+title(field.Name), "Marshal"+it.Name, "err", "return nil, err", field.Type, 0),
+		})
+	}
+	return result
+}
+
+func interfaceOf(t *parser.GoType, interfaces map[string]*parser.Interface) *parser.Interface {
+	if !t.Interface {
+		return nil
+	}
+	for _, it := range interfaces {
+		if it.GoType == t {
+			return it
+		}
+	}
+	return nil
+}
+
+func rawRef(r *parser.TypeRef) string {
+	if r.IsArray() {
+		return "[]" + rawRef(r.Elem)
 	}
-	if isArray {
-		return fmt.Sprintf("[]%s", t.TypeName.Name())
-	} else {
-		return t.TypeName.Name()
+	return "json.RawMessage"
+}
+
+// decode returns the statements decoding raw JSON into an interface value, lists are decoded element by element.
+func decode(target string, raw string, it *parser.Interface, t *parser.GoType, r *parser.TypeRef, depth int) string {
+	if !r.IsArray() {
+		return fmt.Sprintf(`if %s, err = Unmarshal%s(%s); err != nil {
+	return err
+}`, target, it.Name, raw)
+	}
+	index := fmt.Sprintf("i%d", depth)
+	inner := decode(target+"["+index+"]", raw+"["+index+"]", it, t, r.Elem, depth+1)
+	return fmt.Sprintf(`if %s != nil {
+	%s = make(%s, len(%s))
+	for %s := range %s {
+		%s
+	}
+}`, raw, target, modelRef(t, r), raw, index, raw, strings.ReplaceAll(inner, "\n", "\n\t\t"))
+}
+
+// encode returns the statements encoding an interface value to raw JSON with its __typename by calling marshal,
+// lists are encoded element by element. fail is the statement returning the error in errName.
+func encode(target string, value string, marshal string, errName string, fail string, r *parser.TypeRef, depth int) string {
+	if !r.IsArray() {
+		return fmt.Sprintf(`if %s, %s = %s(%s); %s != nil {
+	%s
+}`, target, errName, marshal, value, errName, fail)
+	}
+	index := fmt.Sprintf("i%d", depth)
+	inner := encode(target+"["+index+"]", value+"["+index+"]", marshal, errName, fail, r.Elem, depth+1)
+	return fmt.Sprintf(`if %s != nil {
+	%s = make(%s, len(%s))
+	for %s := range %s {
+		%s
 	}
+}`, value, target, rawRef(r), value, index, value, strings.ReplaceAll(inner, "\n", "\n\t\t"))
+}
+
+// typeCases returns the types of a type switch case matching a member of an interface. Generated models implement
+// their interfaces with value receivers, so values and pointers are matched.
+func typeCases(t *parser.Parent) string {
+	if !t.Autobind && t.TypeName.Pkg().Path() == defaultPackage.PkgPath {
+		return modelRef(t.GoType, &parser.TypeRef{}) + ", " + t.TypeName.Name()
+	}
+	return modelRef(t.GoType, &parser.TypeRef{})
 }
 
 var modelTemplate = template.Must(template.New("model").Funcs(template.FuncMap{
-	"ref":   modelRef,
-	"path":  pkgPath,
-	"title": title,
+	"ref": modelRef,
+	"objects": func(t *parser.GoType) string {
+		return modelRef(t, &parser.TypeRef{Elem: &parser.TypeRef{}})
+	},
+	"object": func(t *parser.GoType) string {
+		return modelRef(t, &parser.TypeRef{})
+	},
+	"getters":         getters,
+	"methods":         methods,
+	"interfaceFields": interfaceFields,
+	"typeCases":       typeCases,
+	"path":            pkgPath,
+	"title":           title,
 }).Parse(`package {{.PackageName}}
 
 import(
@@ -115,8 +297,14 @@ import(
 )
 
 {{- range $model := .Interfaces }}
+{{- if .Union }}
+// {{ .Name }} is a union of {{ range $index, $member := .Types }}{{ if $index }} | {{ end }}{{ $member.Name }}{{ end }}
+{{- end }}
 type {{.Name }} interface {
 	Is{{.Name }}()
+	{{- range $getter := getters $model }}
+	{{ $getter.Name }}() {{ $getter.Type }}
+	{{- end }}
 }
 {{- end }}
 {{- range $model := .Models }}
@@ -124,9 +312,167 @@ type {{ .Name }} struct {
 	{{- range $field := .Fields }}
 		{{- with .Description }}
 		{{- end}}
-		{{ $field.Name | title }} {{ ref $field.GoType $field.IsArray }} ` + "`{{$field.Tag}}`" + `
+		{{ $field.Name | title }} {{ ref $field.GoType $field.Type }} ` + "`{{$field.Tag}}`" + `
+	{{- end }}
+}
+{{- range $method := methods $model $.AllInterfaces }}
+
+func (m {{ $model.Name }}) {{ $method.Name }}() {{ $method.Type }} { {{- with $method.Return }} return {{ . }} {{ end -}} }
+{{- end }}
+{{- with interfaceFields $model $.AllInterfaces }}
+
+func (m *{{ $model.Name }}) UnmarshalJSON(data []byte) error {
+	type alias {{ $model.Name }}
+	var raw struct {
+		*alias
+		{{- range $field := . }}
+		{{ $field.Name }} {{ $field.Raw }} ` + "`{{ $field.Tag }}`" + `
+		{{- end }}
+	}
+	raw.alias = (*alias)(m)
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	{{- range $field := . }}
+	{{ $field.Decode }}
 	{{- end }}
+	return nil
 }
+
+func (m {{ $model.Name }}) MarshalJSON() ([]byte, error) {
+	type alias {{ $model.Name }}
+	raw := struct {
+		alias
+		{{- range $field := . }}
+		{{ $field.Name }} {{ $field.Raw }} ` + "`{{ $field.Tag }}`" + `
+		{{- end }}
+	}{alias: alias(m)}
+	var err error
+	{{- range $field := . }}
+	{{ $field.Encode }}
+	{{- end }}
+	return json.Marshal(raw)
+}
+{{- end }}
+{{- end }}
+{{- range $it := .AllInterfaces }}
+
+// Unmarshal{{ .Name }} decodes a {{ .Name }} into the {{ if .Union }}member{{ else }}implementing type{{ end }} named by typeNames, __typename or dgraph.type, in this order.
+func Unmarshal{{ .Name }}(data []byte, typeNames ...string) ({{ object .GoType }}, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   ` + "`json:\"__typename\"`" + `
+		DgraphType []string ` + "`json:\"dgraph.type\"`" + `
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		{{- range $type := $it.Types }}
+		case "{{ $type.Name }}":
+			var value {{ object $type.GoType }}
+			err := json.Unmarshal(data, &value)
+			return value, err
+		{{- end }}
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of {{ .Name }} from %v", typeNames)
+}
+
+// Marshal{{ .Name }} encodes a {{ .Name }} with the __typename of its {{ if .Union }}member{{ else }}implementing type{{ end }}, which Dgraph needs to resolve the type.
+func Marshal{{ .Name }}(value {{ object .GoType }}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	{{- range $type := $it.Types }}
+	case {{ typeCases $type }}:
+		return withTypeName(data, "{{ $type.Name }}"), nil
+	{{- end }}
+	}
+	return data, nil
+}
+
+// Unmarshal{{ .Name }}List decodes a list of {{ .Name }}.
+func Unmarshal{{ .Name }}List(data []byte, typeNames ...string) ({{ objects .GoType }}, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make({{ objects .GoType }}, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = Unmarshal{{ .Name }}(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+{{- end }}
+{{- if .AllInterfaces }}
+
+// withTypeName adds the __typename to an encoded object.
+func withTypeName(data []byte, typeName string) []byte {
+	if len(data) < 2 || data[0] != '{' {
+		return data
+	}
+	object := []byte("{\"__typename\":\"" + typeName + "\"")
+	if len(data) > 2 {
+		object = append(object, ',')
+	}
+	return append(object, data[1:]...)
+}
+{{- end }}
+{{- range $model := .Webhooks }}
+{{- range $operation := .LambdaOnMutate }}
+{{- if eq $operation "add" }}
+
+type {{ $model.Name }}AddEvent struct {
+	TypeName  string ` + "`json:\"__typename\"`" + `
+	CommitTs  uint64 ` + "`json:\"commitTs\"`" + `
+	Operation string ` + "`json:\"operation\"`" + `
+	Add       *{{ $model.Name }}AddEventInfo ` + "`json:\"add\"`" + `
+}
+
+type {{ $model.Name }}AddEventInfo struct {
+	RootUIDs []string ` + "`json:\"rootUIDs\"`" + `
+	Input    {{ objects $model.AddInput.GoType }} ` + "`json:\"input\"`" + `
+}
+{{- else if eq $operation "update" }}
+
+type {{ $model.Name }}UpdateEvent struct {
+	TypeName  string ` + "`json:\"__typename\"`" + `
+	CommitTs  uint64 ` + "`json:\"commitTs\"`" + `
+	Operation string ` + "`json:\"operation\"`" + `
+	Update    *{{ $model.Name }}UpdateEventInfo ` + "`json:\"update\"`" + `
+}
+
+type {{ $model.Name }}UpdateEventInfo struct {
+	RootUIDs    []string ` + "`json:\"rootUIDs\"`" + `
+	SetPatch    {{ object $model.Patch.GoType }} ` + "`json:\"setPatch\"`" + `
+	RemovePatch {{ object $model.Patch.GoType }} ` + "`json:\"removePatch\"`" + `
+}
+{{- else if eq $operation "delete" }}
+
+type {{ $model.Name }}DeleteEvent struct {
+	TypeName  string ` + "`json:\"__typename\"`" + `
+	CommitTs  uint64 ` + "`json:\"commitTs\"`" + `
+	Operation string ` + "`json:\"operation\"`" + `
+	Delete    *{{ $model.Name }}DeleteEventInfo ` + "`json:\"delete\"`" + `
+}
+
+type {{ $model.Name }}DeleteEventInfo struct {
+	RootUIDs []string ` + "`json:\"rootUIDs\"`" + `
+}
+{{- end }}
+{{- end }}
 {{- end }}
 {{ range $enum := .Enums }}
 type {{$enum.Name }} string
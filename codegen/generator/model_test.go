@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Model_Unmarshal_Union(t *testing.T) {
+	members, err := model.UnmarshalHomeMemberList([]byte(`[{"__typename":"Human","name":"Ann"},{"dgraph.type":["Animal","Dog"],"id":"0x1"},null]`))
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+	assert.Equal(t, &model.Human{Name: "Ann"}, members[0])
+	assert.Equal(t, &model.Dog{Id: "0x1"}, members[1])
+	assert.Nil(t, members[2])
+
+	_, err = model.UnmarshalHomeMember([]byte(`{"name":"Ann"}`))
+	assert.Error(t, err)
+
+	// Fields holding interfaces are decoded by the model
+	var author *model.Author
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"0x2","name":"Ann","posts":[{"__typename":"Question","id":"0x3","title":"Why"}]}`), &author))
+	assert.Equal(t, "Ann", author.Name)
+	require.Len(t, author.Posts, 1)
+	assert.Equal(t, "Why", author.Posts[0].(*model.Question).Title)
+}
+
+func Test_Model_Marshal_Union(t *testing.T) {
+	b, err := model.MarshalHomeMember(&model.Dog{Id: "0x1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"__typename":"Dog","id":"0x1","breed":null,"category":null}`, string(b))
+
+	b, err = model.MarshalHomeMember(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+
+	// Interface fields are encoded with their __typename and decoded again
+	b, err = json.Marshal(&model.Author{Id: "0x2", Posts: []model.Post{&model.Question{Id: "0x3", Title: "Why"}}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"posts":[{"__typename":"Question","id":"0x3"`)
+
+	var author *model.Author
+	require.NoError(t, json.Unmarshal(b, &author))
+	assert.Equal(t, "Why", author.Posts[0].GetTitle())
+}
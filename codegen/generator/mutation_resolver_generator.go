@@ -77,7 +77,7 @@ import(
 
 type MutationResolverInterface interface {
 {{- range $mutationResolver := .MutationResolvers}}
-	Mutation_{{$mutationResolver.Name}}(ctx context.Context{{ if ne (len $mutationResolver.Arguments) 0}}, {{ $mutationResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $mutationResolver.Return.GoType $mutationResolver.Return.IsArray }}, *api.LambdaError){{ end }}
+	Mutation_{{$mutationResolver.Name}}(ctx context.Context{{ if ne (len $mutationResolver.Arguments) 0}}, {{ $mutationResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $mutationResolver.Return.GoType $mutationResolver.Return.Type }}, *api.LambdaError){{ end }}
 }
 
 type MutationResolver struct {
@@ -85,7 +85,7 @@ type MutationResolver struct {
 }
 
 {{- range $mutationResolver := .MutationResolvers}}
-func (q *MutationResolver) Mutation_{{$mutationResolver.Name}}(ctx context.Context{{ if ne (len $mutationResolver.Arguments) 0}}, {{ $mutationResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $mutationResolver.Return.GoType $mutationResolver.Return.IsArray }}, *api.LambdaError) { {{ body $mutationResolver.Return.GoType $mutationResolver.Return.IsArray (printf "Mutation_%s" $mutationResolver.Name) $.Rewriter }}}
+func (q *MutationResolver) Mutation_{{$mutationResolver.Name}}(ctx context.Context{{ if ne (len $mutationResolver.Arguments) 0}}, {{ $mutationResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $mutationResolver.Return.GoType $mutationResolver.Return.Type }}, *api.LambdaError) { {{ body $mutationResolver.Return.GoType $mutationResolver.Return.Type (printf "Mutation_%s" $mutationResolver.Name) $.Rewriter }}}
 {{ end }}
 
 {{- range $key, $depBody := .Rewriter.DeprecatedBodies }}
@@ -2,7 +2,6 @@ package generator
 
 import (
 	"errors"
-	"fmt"
 	"go/types"
 	"os"
 	"path"
@@ -61,21 +60,8 @@ func generateQueryResolvers(c *config.Config, parsedTree *parser.Tree, r *rewrit
 	return errors.New("Resolver file pattern invalid")
 }
 
-func returnRef(t *parser.GoType, isArray bool) string {
-	if t.TypeName.Pkg() != nil {
-		if t.TypeName.Exported() {
-			if isArray {
-				return fmt.Sprintf("[]*%s.%s", t.TypeName.Pkg().Name(), t.TypeName.Name())
-			} else {
-				return fmt.Sprintf("*%s.%s", t.TypeName.Pkg().Name(), t.TypeName.Name())
-			}
-		}
-	}
-	if isArray {
-		return fmt.Sprintf("[]%s", t.TypeName.Name())
-	} else {
-		return t.TypeName.Name()
-	}
+func returnRef(t *parser.GoType, r *parser.TypeRef) string {
+	return pointer(t, r)
 }
 
 var queryResolverTemplate = template.Must(template.New("query-resolver").Funcs(template.FuncMap{
@@ -95,7 +81,7 @@ import(
 
 type QueryResolverInterface interface {
 {{- range $queryResolver := .QueryResolvers}}
-	Query_{{$queryResolver.Name}}(ctx context.Context{{ if ne (len $queryResolver.Arguments) 0}}, {{ $queryResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $queryResolver.Return.GoType $queryResolver.Return.IsArray }}, *api.LambdaError){{ end }}
+	Query_{{$queryResolver.Name}}(ctx context.Context{{ if ne (len $queryResolver.Arguments) 0}}, {{ $queryResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $queryResolver.Return.GoType $queryResolver.Return.Type }}, *api.LambdaError){{ end }}
 }
 
 type QueryResolver struct {
@@ -103,7 +89,7 @@ type QueryResolver struct {
 }
 
 {{- range $queryResolver := .QueryResolvers}}
-func (q *QueryResolver) Query_{{$queryResolver.Name}}(ctx context.Context{{ if ne (len $queryResolver.Arguments) 0}}, {{ $queryResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $queryResolver.Return.GoType $queryResolver.Return.IsArray }}, *api.LambdaError) { {{ body $queryResolver.Return.GoType $queryResolver.Return.IsArray (printf "Query_%s" $queryResolver.Name) $.Rewriter }}}
+func (q *QueryResolver) Query_{{$queryResolver.Name}}(ctx context.Context{{ if ne (len $queryResolver.Arguments) 0}}, {{ $queryResolver.Arguments | argsW }}{{ end }}, authHeader api.AuthHeader) ({{ ref $queryResolver.Return.GoType $queryResolver.Return.Type }}, *api.LambdaError) { {{ body $queryResolver.Return.GoType $queryResolver.Return.Type (printf "Query_%s" $queryResolver.Name) $.Rewriter }}}
 {{ end }}
 
 {{- range $key, $depBody := .Rewriter.DeprecatedBodies }}
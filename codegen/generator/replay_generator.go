@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"os"
+	"path"
+	"text/template"
+
+	"github.com/schartey/dgraph-lambda-go/codegen/config"
+)
+
+// GenerateReplay writes a main package that replays recordings against the generated executer.
+func GenerateReplay(config *config.Config, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return replayTemplate.Execute(f, struct {
+		ResolverPath     string
+		ResolverPackage  string
+		GeneratedPath    string
+		GeneratedPackage string
+	}{
+		ResolverPath:     path.Join(config.Root, config.Resolver.Dir),
+		ResolverPackage:  config.Resolver.Package,
+		GeneratedPath:    path.Join(config.Root, path.Dir(config.Exec.Filename)),
+		GeneratedPackage: config.Exec.Package,
+	})
+}
+
+var replayTemplate = template.Must(template.New("replay").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"{{ .GeneratedPath }}"
+	"{{ .ResolverPath }}"
+)
+
+func main() {
+	resolver := &{{ .ResolverPackage }}.Resolver{}
+	// Replayed webhooks must not reach the configured sinks or the subscribers of the default event bus
+	executer := {{ .GeneratedPackage }}.NewExecuterWithOptions(resolver, api.ExecuterOptions{Sinks: api.NewSinkRouter(), EventBus: api.NewEventBus()})
+
+	options := api.ReplayOptions{ID: os.Args[2], AccessToken: os.Getenv(api.ReplayAccessTokenEnv), AuthHeader: os.Getenv(api.ReplayAuthHeaderEnv)}
+	if err := api.ReplayRecordings(executer, os.Args[1], options, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+`))
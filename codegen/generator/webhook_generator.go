@@ -24,30 +24,24 @@ func generateWebhook(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rewr
 
 		pkgs := make(map[string]*types.Package)
 
-		var models = make(map[string]*parser.Model)
-
-		for _, m := range parsedTree.ModelTree.Models {
-			if len(m.LambdaOnMutate) > 0 {
-				models[m.Name] = m
-				//pkgs[m.TypeName.Pkg().Name()] = m.TypeName.Pkg()
-			}
-		}
-
-		if len(models) > 0 {
+		if len(parsedTree.ResolverTree.Webhooks) > 0 {
+			pkgs[c.DefaultModelPackage.Name] = types.NewPackage(c.DefaultModelPackage.PkgPath, c.DefaultModelPackage.Name)
 			pkgs["context"] = types.NewPackage("context", "context")
 			pkgs["api"] = types.NewPackage("github.com/schartey/dgraph-lambda-go/api", "api")
 		}
 
 		err = webhookResolverTemplate.Execute(f, struct {
-			Models      map[string]*parser.Model
-			Rewriter    *rewriter.Rewriter
-			Packages    map[string]*types.Package
-			PackageName string
+			Webhooks         map[string]*parser.Webhook
+			Rewriter         *rewriter.Rewriter
+			Packages         map[string]*types.Package
+			PackageName      string
+			ModelPackageName string
 		}{
-			Models:      models,
-			Rewriter:    r,
-			Packages:    pkgs,
-			PackageName: c.Resolver.Package,
+			Webhooks:         parsedTree.ResolverTree.Webhooks,
+			Rewriter:         r,
+			Packages:         pkgs,
+			PackageName:      c.Resolver.Package,
+			ModelPackageName: c.DefaultModelPackage.Name,
 		})
 		if err != nil {
 			return err
@@ -58,10 +52,10 @@ func generateWebhook(c *config.Config, parsedTree *parser.Tree, r *rewriter.Rewr
 }
 
 var webhookResolverTemplate = template.Must(template.New("webhook-resolver").Funcs(template.FuncMap{
-	"path":     pkgPath,
-	"body":     middlewareBody,
-	"typeName": typeName,
-	"is":       is,
+	"path":      pkgPath,
+	"body":      middlewareBody,
+	"is":        is,
+	"eventType": eventType,
 }).Parse(`
 package {{.PackageName}}
 
@@ -71,15 +65,21 @@ import(
 )
 
 type WebhookResolverInterface interface {
-{{- range $model := .Models}}
-	Webhook_{{ $model.TypeName | typeName }}(ctx context.Context, event *api.Event) *api.LambdaError{{ end }}
+{{- range $webhook := .Webhooks}}{{ range $event := $webhook.LambdaOnMutate }}
+	Webhook_{{ $webhook.Name }}_{{ $event }}(ctx context.Context, event *{{ $.ModelPackageName }}.{{ eventType $webhook.Name $event }}) *api.LambdaError{{ end }}{{ end }}
 }
 
 type WebhookResolver struct {
 	*Resolver
 }
 
-{{ range $model := .Models}}
-func (w *WebhookResolver) Webhook_{{ $model.TypeName | typeName }}(ctx context.Context, event *api.Event) *api.LambdaError { {{ body (printf "Webhook_%s" ($model.TypeName | typeName)) $.Rewriter }}}
+{{ range $webhook := .Webhooks}}{{ range $event := $webhook.LambdaOnMutate }}
+func (w *WebhookResolver) Webhook_{{ $webhook.Name }}_{{ $event }}(ctx context.Context, event *{{ $.ModelPackageName }}.{{ eventType $webhook.Name $event }}) *api.LambdaError { {{ body (printf "Webhook_%s_%s" $webhook.Name $event) $.Rewriter }}}
+{{ end }}{{ end }}
+
+{{- range $key, $depBody := .Rewriter.DeprecatedBodies }}
+{{ if is $key "Webhook_" }}
+/* {{ $depBody }} */
+{{ end }}
 {{ end }}
 `))
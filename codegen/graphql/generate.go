@@ -0,0 +1,437 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Filters Dgraph generates for the indexes of @search
+var stringFilters = map[string]string{
+	"hash":     "StringHashFilter",
+	"exact":    "StringExactFilter",
+	"term":     "StringTermFilter",
+	"fulltext": "StringFullTextFilter",
+	"regexp":   "StringRegExpFilter",
+	"trigram":  "StringRegExpFilter",
+}
+
+var typeFilters = map[string]string{
+	"Int":          "IntFilter",
+	"Int64":        "Int64Filter",
+	"Float":        "FloatFilter",
+	"DateTime":     "DateTimeFilter",
+	"Boolean":      "Boolean",
+	"Point":        "PointGeoFilter",
+	"Polygon":      "PolygonGeoFilter",
+	"MultiPolygon": "PolygonGeoFilter",
+}
+
+var orderable = map[string]bool{"Int": true, "Int64": true, "Float": true, "String": true, "DateTime": true}
+
+// Geo types are referenced by their input in mutations
+var geoTypes = map[string]bool{"Point": true, "PointList": true, "Polygon": true, "MultiPolygon": true}
+
+type expander struct {
+	definitions []*ast.Definition
+	types       map[string]*ast.Definition
+	filters     map[string]*ast.Definition
+	generated   map[string]bool
+	out         strings.Builder
+}
+
+// ExpandSchema returns a source with the types Dgraph generates for the types of the schema, like AddUserInput,
+// UserPatch, UserRef, UserFilter, UserOrder and the mutation payloads. Only named sources are expanded, the Dgraph
+// definitions are not. Types already declared in the schema are not generated again.
+func ExpandSchema(sources []*ast.Source) (*ast.Source, error) {
+	e := &expander{
+		types:     make(map[string]*ast.Definition),
+		filters:   make(map[string]*ast.Definition),
+		generated: make(map[string]bool),
+	}
+
+	filterDoc, gqlErr := parser.ParseSchema(&ast.Source{Name: "filters", Input: FilterInputs})
+	if gqlErr != nil {
+		return nil, gqlErr
+	}
+	for _, def := range filterDoc.Definitions {
+		e.filters[def.Name] = def
+	}
+
+	var extensions []*ast.Definition
+	for _, source := range sources {
+		if source.Name == "" {
+			continue
+		}
+		doc, gqlErr := parser.ParseSchema(source)
+		if gqlErr != nil {
+			return nil, gqlErr
+		}
+		for _, def := range doc.Definitions {
+			if _, ok := e.types[def.Name]; !ok {
+				e.definitions = append(e.definitions, def)
+				e.types[def.Name] = def
+			}
+		}
+		extensions = append(extensions, doc.Extensions...)
+	}
+	for _, extension := range extensions {
+		if def, ok := e.types[extension.Name]; ok {
+			def.Fields = append(def.Fields, extension.Fields...)
+			def.Directives = append(def.Directives, extension.Directives...)
+		}
+	}
+
+	for _, def := range filterDoc.Definitions {
+		e.write(def.Name, "input %s {\n%s}\n", def.Name, fieldLines(def.Fields))
+	}
+
+	for _, def := range e.definitions {
+		if def.Directives.ForName("remote") != nil {
+			continue
+		}
+		switch def.Kind {
+		case ast.Object:
+			if def.Name == "Query" || def.Name == "Mutation" || def.Name == "Subscription" {
+				continue
+			}
+			e.expandType(def)
+		case ast.Interface:
+			e.expandType(def)
+		case ast.Union:
+			e.expandUnion(def)
+		}
+	}
+
+	return &ast.Source{Name: "dgraph.graphql", Input: e.out.String(), BuiltIn: true}, nil
+}
+
+func (e *expander) expandType(def *ast.Definition) {
+	add := def.Kind == ast.Object && generate(def, "mutation", "add")
+	update := generate(def, "mutation", "update")
+	remove := generate(def, "mutation", "delete")
+	secret := SecretField(def)
+
+	var inputFields, patchFields, refFields []string
+	for _, field := range e.stored(def) {
+		if field.Type.Name() == "ID" {
+			continue
+		}
+		inputFields = append(inputFields, field.Name+": "+e.refType(field.Type, false))
+		patchFields = append(patchFields, field.Name+": "+e.refType(field.Type, true))
+		refFields = append(refFields, field.Name+": "+e.refType(field.Type, true))
+	}
+	if secret != "" {
+		inputFields = append(inputFields, secret+": String!")
+		patchFields = append(patchFields, secret+": String")
+	}
+	if id := idField(def); id != nil {
+		refFields = append([]string{id.Name + ": ID"}, refFields...)
+	}
+	if def.Kind == ast.Interface {
+		// Interfaces are referenced by the id of an implementing type
+		refFields = nil
+		if id := idField(def); id != nil {
+			refFields = []string{id.Name + ": ID!"}
+		}
+	}
+
+	if add && len(inputFields) > 0 {
+		e.write("Add"+def.Name+"Input", "input Add%sInput {\n%s}\n", def.Name, lines(inputFields))
+	}
+	if update && len(patchFields) > 0 {
+		e.write(def.Name+"Patch", "input %sPatch {\n%s}\n", def.Name, lines(patchFields))
+		e.write("Update"+def.Name+"Input", "input Update%sInput {\n\tfilter: %sFilter!\n\tset: %sPatch\n\tremove: %sPatch\n}\n", def.Name, def.Name, def.Name, def.Name)
+	}
+	if len(refFields) > 0 {
+		e.write(def.Name+"Ref", "input %sRef {\n%s}\n", def.Name, lines(refFields))
+	}
+
+	e.expandFilter(def)
+	ordered := e.expandOrder(def)
+
+	// The payloads select the mutated objects like a query field
+	args := fmt.Sprintf("filter: %sFilter, ", def.Name)
+	if ordered {
+		args += fmt.Sprintf("order: %sOrder, ", def.Name)
+	}
+	args += "first: Int, offset: Int"
+	field := fmt.Sprintf("\t%s(%s): [%s]\n", lowerFirst(def.Name), args, def.Name)
+	if add {
+		e.write("Add"+def.Name+"Payload", "type Add%sPayload {\n%s\tnumUids: Int\n}\n", def.Name, field)
+	}
+	if update && len(patchFields) > 0 {
+		e.write("Update"+def.Name+"Payload", "type Update%sPayload {\n%s\tnumUids: Int\n}\n", def.Name, field)
+	}
+	if remove {
+		e.write("Delete"+def.Name+"Payload", "type Delete%sPayload {\n%s\tmsg: String\n\tnumUids: Int\n}\n", def.Name, field)
+	}
+}
+
+func (e *expander) expandFilter(def *ast.Definition) {
+	var fields, has []string
+	if id := idField(def); id != nil {
+		fields = append(fields, id.Name+": [ID!]")
+	}
+	for _, field := range e.stored(def) {
+		if field.Type.Name() == "ID" {
+			continue
+		}
+		has = append(has, field.Name)
+		if filter := e.searchFilter(field); filter != "" {
+			fields = append(fields, field.Name+": "+filter)
+		}
+	}
+	if len(has) > 0 {
+		e.write(def.Name+"HasFilter", "enum %sHasFilter {\n%s}\n", def.Name, lines(has))
+		fields = append(fields, fmt.Sprintf("has: [%sHasFilter]", def.Name))
+	}
+	fields = append(fields,
+		fmt.Sprintf("and: [%sFilter]", def.Name),
+		fmt.Sprintf("or: [%sFilter]", def.Name),
+		fmt.Sprintf("not: %sFilter", def.Name))
+	e.write(def.Name+"Filter", "input %sFilter {\n%s}\n", def.Name, lines(fields))
+}
+
+func (e *expander) expandOrder(def *ast.Definition) bool {
+	var fields []string
+	for _, field := range e.stored(def) {
+		if field.Type.Elem == nil && orderable[field.Type.Name()] {
+			fields = append(fields, field.Name)
+		}
+	}
+	if len(fields) == 0 {
+		return false
+	}
+	e.write(def.Name+"Orderable", "enum %sOrderable {\n%s}\n", def.Name, lines(fields))
+	e.write(def.Name+"Order", "input %sOrder {\n\tasc: %sOrderable\n\tdesc: %sOrderable\n\tthen: %sOrder\n}\n", def.Name, def.Name, def.Name, def.Name)
+	return true
+}
+
+func (e *expander) expandUnion(def *ast.Definition) {
+	var refs, filters []string
+	for _, member := range def.Types {
+		refs = append(refs, fmt.Sprintf("%sRef: %sRef", lowerFirst(member), member))
+		filters = append(filters, fmt.Sprintf("%sFilter: %sFilter", lowerFirst(member), member))
+	}
+	e.write(def.Name+"Type", "enum %sType {\n%s}\n", def.Name, lines(def.Types))
+	e.write(def.Name+"Ref", "input %sRef {\n%s}\n", def.Name, lines(refs))
+	e.write(def.Name+"Filter", "input %sFilter {\n%s}\n", def.Name, lines(append([]string{fmt.Sprintf("memberTypes: [%sType!]", def.Name)}, filters...)))
+}
+
+// searchFilter returns the filter of a field with @search or @id. Fields with several indexes get a filter combining
+// the filters of all indexes, like StringHashFilter_StringTermFilter.
+func (e *expander) searchFilter(field *ast.FieldDefinition) string {
+	typeName := field.Type.Name()
+	search := field.Directives.ForName("search")
+	if search == nil {
+		if field.Directives.ForName("id") == nil {
+			return ""
+		}
+		// @id adds a hash index to strings
+		if typeName == "String" {
+			return "StringHashFilter"
+		}
+		return typeFilters[typeName]
+	}
+
+	var indexes []string
+	if by := search.Arguments.ForName("by"); by != nil {
+		for _, child := range by.Value.Children {
+			indexes = append(indexes, child.Value.Raw)
+		}
+	}
+
+	if def, ok := e.types[typeName]; ok && def.Kind == ast.Enum {
+		if len(indexes) == 0 {
+			indexes = []string{"hash"}
+		}
+		var names []string
+		for _, index := range indexes {
+			switch index {
+			case "hash", "exact":
+				name := typeName + "_" + index
+				e.write(name, "input %s {\n%s}\n", name, fieldLines(e.filterFields(name)))
+				names = append(names, name)
+			case "regexp", "trigram":
+				names = append(names, "StringRegExpFilter")
+			}
+		}
+		return e.combine(names)
+	}
+
+	if typeName == "String" {
+		if len(indexes) == 0 {
+			indexes = []string{"term"}
+		}
+		var names []string
+		for _, index := range indexes {
+			if name, ok := stringFilters[index]; ok {
+				names = append(names, name)
+			}
+		}
+		return e.combine(names)
+	}
+	return typeFilters[typeName]
+}
+
+func (e *expander) combine(names []string) string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	if len(unique) < 2 {
+		return strings.Join(unique, "")
+	}
+
+	name := strings.Join(unique, "_")
+	var fields ast.FieldList
+	fieldNames := make(map[string]bool)
+	for _, component := range unique {
+		for _, f := range e.filterFields(component) {
+			if !fieldNames[f.Name] {
+				fieldNames[f.Name] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	e.write(name, "input %s {\n%s}\n", name, fieldLines(fields))
+	return name
+}
+
+func (e *expander) filterFields(name string) ast.FieldList {
+	if def, ok := e.filters[name]; ok {
+		return def.Fields
+	}
+	// Enum filters are generated, their fields are those of the string filters with the enum type
+	if i := strings.LastIndex(name, "_"); i > 0 {
+		typeName, index := name[:i], name[i+1:]
+		var fields ast.FieldList
+		for _, f := range e.filters["String"+strings.Title(index)+"Filter"].Fields {
+			copied := *f
+			copied.Type = replaceNamed(f.Type, "String", typeName)
+			fields = append(fields, &copied)
+		}
+		return fields
+	}
+	return nil
+}
+
+// stored returns the fields Dgraph stores, lambda and custom fields are resolved on every request.
+func (e *expander) stored(def *ast.Definition) ast.FieldList {
+	var fields ast.FieldList
+	for _, field := range def.Fields {
+		if field.Directives.ForName("lambda") != nil || field.Directives.ForName("custom") != nil || strings.HasPrefix(field.Name, "__") {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// refType returns the type of a field in inputs, objects are referenced by their Ref input. Fields of patches and
+// references are nullable. Fields with @hasInverse are set like any other reference, Dgraph adds the inverse edge.
+func (e *expander) refType(t *ast.Type, nullable bool) string {
+	var named string
+	if t.Elem != nil {
+		named = "[" + e.refType(t.Elem, false) + "]"
+	} else {
+		named = t.NamedType
+		if def, ok := e.types[named]; ok && (def.Kind == ast.Object || def.Kind == ast.Interface || def.Kind == ast.Union) {
+			named += "Ref"
+		} else if geoTypes[named] {
+			named += "Ref"
+		}
+	}
+	if t.NonNull && !nullable {
+		named += "!"
+	}
+	return named
+}
+
+func (e *expander) write(name string, format string, args ...interface{}) {
+	if e.generated[name] {
+		return
+	}
+	e.generated[name] = true
+	if _, ok := e.types[name]; ok {
+		return
+	}
+	fmt.Fprintf(&e.out, format, args...)
+}
+
+// generate reads a flag of @generate, all operations are generated by default.
+func generate(def *ast.Definition, group string, flag string) bool {
+	directive := def.Directives.ForName("generate")
+	if directive == nil {
+		return true
+	}
+	arg := directive.Arguments.ForName(group)
+	if arg == nil || arg.Value == nil {
+		return true
+	}
+	for _, child := range arg.Value.Children {
+		if child.Name == flag {
+			return child.Value.Raw != "false"
+		}
+	}
+	return true
+}
+
+// SecretField returns the password field declared with Dgraph's @secret directive.
+func SecretField(def *ast.Definition) string {
+	if secret := def.Directives.ForName("secret"); secret != nil {
+		if arg := secret.Arguments.ForName("field"); arg != nil {
+			return arg.Value.Raw
+		}
+	}
+	return ""
+}
+
+func idField(def *ast.Definition) *ast.FieldDefinition {
+	for _, field := range def.Fields {
+		if field.Type.Name() == "ID" {
+			return field
+		}
+	}
+	return nil
+}
+
+func replaceNamed(t *ast.Type, from string, to string) *ast.Type {
+	copied := *t
+	if t.Elem != nil {
+		copied.Elem = replaceNamed(t.Elem, from, to)
+	} else if t.NamedType == from {
+		copied.NamedType = to
+	}
+	return &copied
+}
+
+func fieldLines(fields ast.FieldList) string {
+	var result []string
+	for _, field := range fields {
+		result = append(result, field.Name+": "+field.Type.String())
+	}
+	return lines(result)
+}
+
+func lines(values []string) string {
+	var b strings.Builder
+	for _, value := range values {
+		b.WriteString("\t" + value + "\n")
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func Test_ExpandSchema(t *testing.T) {
+	input, err := ioutil.ReadFile("../../test_resources/dgraph_types.graphql")
+	require.NoError(t, err)
+	sources := []*ast.Source{
+		{Input: SchemaInputs + DirectiveDefs},
+		{Name: "dgraph_types.graphql", Input: string(input)},
+	}
+
+	source, err := ExpandSchema(sources)
+	require.NoError(t, err)
+	schema, gqlErr := gqlparser.LoadSchema(append(sources, source)...)
+	require.Nil(t, gqlErr)
+
+	fieldType := func(typeName string, field string) string {
+		def := schema.Types[typeName]
+		require.NotNil(t, def, typeName)
+		f := def.Fields.ForName(field)
+		if f == nil {
+			return ""
+		}
+		return f.Type.String()
+	}
+
+	// Ids and lambda fields are not stored, objects are referenced, @secret adds the password
+	assert.Equal(t, "", fieldType("AddUserInput", "id"))
+	assert.Equal(t, "", fieldType("AddUserInput", "rank"))
+	assert.Equal(t, "String!", fieldType("AddUserInput", "username"))
+	assert.Equal(t, "[PostRef]", fieldType("AddUserInput", "posts"))
+	assert.Equal(t, "String!", fieldType("AddUserInput", "password"))
+	assert.Equal(t, "String", fieldType("UserPatch", "username"))
+	assert.Equal(t, "String", fieldType("UserPatch", "password"))
+	assert.Equal(t, "ID", fieldType("UserRef", "id"))
+
+	// @search and @id select the filters
+	assert.Equal(t, "StringHashFilter", fieldType("UserFilter", "username"))
+	assert.Equal(t, "StringHashFilter_StringTermFilter", fieldType("UserFilter", "name"))
+	assert.Equal(t, "Role_hash", fieldType("UserFilter", "role"))
+	assert.Equal(t, "IntFilter", fieldType("UserFilter", "age"))
+	assert.Equal(t, "", fieldType("UserFilter", "tags"))
+	assert.Equal(t, "StringFullTextFilter", fieldType("PostFilter", "title"))
+	assert.Equal(t, "String", fieldType("StringHashFilter_StringTermFilter", "anyofterms"))
+	assert.Len(t, schema.Types["UserOrderable"].EnumValues, 3)
+
+	// @generate disables the delete mutation
+	assert.NotNil(t, schema.Types["AddUserPayload"])
+	assert.Nil(t, schema.Types["DeleteUserPayload"])
+	assert.NotNil(t, schema.Types["DeletePostPayload"])
+
+	assert.Equal(t, "UserRef", fieldType("MemberRef", "userRef"))
+	assert.Equal(t, "[MemberType!]", fieldType("MemberFilter", "memberTypes"))
+}
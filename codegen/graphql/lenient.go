@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// InheritInterfaceFields copies the fields of interfaces into the types implementing them. Dgraph allows types to
+// omit the fields of their interfaces, the GraphQL spec does not. Only sources with inherited fields are rewritten.
+// Conflicting declarations are returned as warnings and left to the validation of the schema.
+func InheritInterfaceFields(sources []*ast.Source) ([]*ast.Source, []string, error) {
+	docs := make([]*ast.SchemaDocument, len(sources))
+	interfaces := make(map[string]*ast.Definition)
+	declared := make(map[string]ast.FieldList)
+
+	for i, source := range sources {
+		if source.Name == "" {
+			continue
+		}
+		doc, gqlErr := parser.ParseSchema(source)
+		if gqlErr != nil {
+			return nil, nil, gqlErr
+		}
+		docs[i] = doc
+		for _, def := range append(append(ast.DefinitionList{}, doc.Definitions...), doc.Extensions...) {
+			if def.Kind == ast.Interface {
+				if _, ok := interfaces[def.Name]; !ok {
+					interfaces[def.Name] = def
+				}
+			}
+			declared[def.Name] = append(declared[def.Name], def.Fields...)
+		}
+	}
+	// Fields of interface extensions are inherited as well. They are collected separately, so the interface itself
+	// is not rewritten with the fields of an extension that stays in its source.
+	inheritable := make(map[string]ast.FieldList)
+	for name, it := range interfaces {
+		inheritable[name] = append(ast.FieldList{}, it.Fields...)
+	}
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		for _, extension := range doc.Extensions {
+			if it, ok := interfaces[extension.Name]; ok && it != extension {
+				inheritable[extension.Name] = append(inheritable[extension.Name], extension.Fields...)
+			}
+		}
+	}
+
+	var warnings []string
+	result := make([]*ast.Source, len(sources))
+	for i, source := range sources {
+		result[i] = source
+		if docs[i] == nil {
+			continue
+		}
+
+		changed := false
+		for _, def := range docs[i].Definitions {
+			if def.Kind != ast.Object && def.Kind != ast.Interface {
+				continue
+			}
+
+			var inherited ast.FieldList
+			from := make(map[string]string)
+			for _, name := range def.Interfaces {
+				fields, ok := inheritable[name]
+				if !ok {
+					continue
+				}
+				for _, field := range fields {
+					if own := declared[def.Name].ForName(field.Name); own != nil {
+						if own.Type.String() != field.Type.String() {
+							warnings = append(warnings, fmt.Sprintf("%s.%s is declared as %s, but as %s in interface %s", def.Name, field.Name, own.Type.String(), field.Type.String(), name))
+						}
+						continue
+					}
+					if other, ok := from[field.Name]; ok {
+						if inherited.ForName(field.Name).Type.String() != field.Type.String() {
+							warnings = append(warnings, fmt.Sprintf("%s inherits %s from %s and %s with different types, using the type of %s", def.Name, field.Name, other, name, other))
+						}
+						continue
+					}
+					copied := *field
+					inherited = append(inherited, &copied)
+					from[field.Name] = name
+				}
+			}
+
+			if len(inherited) > 0 {
+				// Inherited fields come first, like in the interface
+				def.Fields = append(inherited, def.Fields...)
+				declared[def.Name] = append(declared[def.Name], inherited...)
+				changed = true
+			}
+		}
+
+		if changed {
+			var b strings.Builder
+			formatter.NewFormatter(&b).FormatSchemaDocument(docs[i])
+			result[i] = &ast.Source{Name: source.Name, Input: b.String(), BuiltIn: source.BuiltIn}
+		}
+	}
+	return result, warnings, nil
+}
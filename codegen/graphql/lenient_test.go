@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func Test_InheritInterfaceFields(t *testing.T) {
+	input, err := ioutil.ReadFile("../../test_resources/inherited.graphql")
+	require.NoError(t, err)
+	dgraph := &ast.Source{Input: SchemaInputs + DirectiveDefs}
+	sources := []*ast.Source{dgraph, {Name: "inherited.graphql", Input: string(input)}}
+
+	_, gqlErr := gqlparser.LoadSchema(sources...)
+	require.NotNil(t, gqlErr)
+
+	sources, warnings, err := InheritInterfaceFields(sources)
+	require.NoError(t, err)
+	assert.Same(t, dgraph, sources[0])
+	assert.Equal(t, []string{
+		"Comment.title is declared as String, but as String! in interface Post",
+		"Comment inherits score from Post and Ranked with different types, using the type of Post",
+	}, warnings)
+
+	var names []string
+	for _, field := range definition(t, sources[1], "Question").Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"id", "title", "score", "answered"}, names)
+
+	// Directives and descriptions are inherited, like the middleware of a field
+	title := definition(t, sources[1], "Question").Fields.ForName("title")
+	assert.NotNil(t, title.Directives.ForName("search"))
+	assert.Contains(t, title.Description, `@middleware(["user"])`)
+
+	// Conflicts are still reported by the validation
+	_, gqlErr = gqlparser.LoadSchema(sources...)
+	require.NotNil(t, gqlErr)
+	assert.Contains(t, gqlErr.Error(), "Comment")
+
+	sources, warnings, err = InheritInterfaceFields([]*ast.Source{dgraph, {Name: "schema.graphql", Input: `
+		interface Named { id: ID!, name: String }
+		type User implements Named { age: Int }`}})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	schema, gqlErr := gqlparser.LoadSchema(sources...)
+	require.Nil(t, gqlErr)
+	assert.NotNil(t, schema.Types["User"].Fields.ForName("name"))
+
+	// Extensions in other sources are inherited, but stay where they are declared
+	sources, warnings, err = InheritInterfaceFields([]*ast.Source{dgraph,
+		{Name: "a.graphql", Input: `interface I { id: ID! }
+			type T implements I { name: String }`},
+		{Name: "b.graphql", Input: `extend interface I { x: Int }`},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Nil(t, definition(t, sources[1], "I").Fields.ForName("x"))
+	schema, gqlErr = gqlparser.LoadSchema(sources...)
+	require.Nil(t, gqlErr)
+	assert.NotNil(t, schema.Types["T"].Fields.ForName("x"))
+	assert.Len(t, schema.Types["I"].Fields, 2)
+}
+
+func definition(t *testing.T, source *ast.Source, name string) *ast.Definition {
+	doc, gqlErr := parser.ParseSchema(source)
+	require.Nil(t, gqlErr)
+	return doc.Definitions.ForName(name)
+}
+
+func Test_InheritInterfaceFields_Round_Trip(t *testing.T) {
+	source := &ast.Source{Name: "schema.graphql", Input: `
+"""
+Users log in with @middleware(["auth"])
+"""
+type User @secret(field: "pwd", pred: "User.password") @lambdaOnMutate(add: true, delete: true) {
+	id: ID!
+	"""
+	@middleware(["admin"])
+	"""
+	email: String! @id @search(by: [hash]) @dgraph(pred: "User.mail")
+	posts(first: Int = 10): [Post] @hasInverse(field: author)
+}
+
+interface Post {
+	id: ID!
+	"The title @middleware([\"user\"])"
+	title: String! @search(by: [term, fulltext])
+	author: User
+}
+
+# Question omits the fields of Post
+type Question implements Post @dgraph(type: "Forum.Question") {
+	answered: Boolean @lambda
+}
+
+enum Tag {
+	"Used for news"
+	NEWS
+	GO
+}`}
+	original, gqlErr := parser.ParseSchema(source)
+	require.Nil(t, gqlErr)
+
+	sources, _, err := InheritInterfaceFields([]*ast.Source{{Input: SchemaInputs + DirectiveDefs}, source})
+	require.NoError(t, err)
+	require.NotSame(t, source, sources[1])
+	rewritten, gqlErr := parser.ParseSchema(sources[1])
+	require.Nil(t, gqlErr)
+
+	// Descriptions and directives of the rewritten source are the ones of the original
+	for _, def := range original.Definitions {
+		got := rewritten.Definitions.ForName(def.Name)
+		require.NotNil(t, got, def.Name)
+		assert.Equal(t, def.Description, got.Description, def.Name)
+		assert.Equal(t, directives(def.Directives), directives(got.Directives), def.Name)
+		for _, field := range def.Fields {
+			gotField := got.Fields.ForName(field.Name)
+			require.NotNil(t, gotField, def.Name+"."+field.Name)
+			assert.Equal(t, field.Description, gotField.Description, def.Name+"."+field.Name)
+			assert.Equal(t, field.Type.String(), gotField.Type.String(), def.Name+"."+field.Name)
+			assert.Equal(t, directives(field.Directives), directives(gotField.Directives), def.Name+"."+field.Name)
+			for _, arg := range field.Arguments {
+				assert.Equal(t, arg.DefaultValue.String(), gotField.Arguments.ForName(arg.Name).DefaultValue.String())
+			}
+		}
+		for _, value := range def.EnumValues {
+			assert.Equal(t, value.Description, got.EnumValues.ForName(value.Name).Description)
+		}
+	}
+
+	_, gqlErr = gqlparser.LoadSchema(sources...)
+	require.Nil(t, gqlErr)
+}
+
+func directives(list ast.DirectiveList) []string {
+	var result []string
+	for _, directive := range list {
+		s := directive.Name
+		for _, arg := range directive.Arguments {
+			s += " " + arg.Name + ":" + arg.Value.String()
+		}
+		result = append(result, s)
+	}
+	return result
+}
@@ -77,10 +77,6 @@ func SchemaDefToGoDef(def *ast.Definition) (pkgPath string, typeName string, err
 	return pkgPath, typeName, nil
 }
 
-func IsArray(name string) bool {
-	return strings.HasPrefix(name, "[") && (strings.HasSuffix(name, "]") || strings.HasSuffix(name, "]!"))
-}
-
 func IsDgraphType(name string) bool {
 	return inbuiltTypeToDgraph[name] != ""
 }
@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// LambdaField returns the @lambda field Dgraph calls when resolving a field of a type. Types without @lambda on the
+// field itself are resolved by the @lambda field of their interface.
+func LambdaField(schema *ast.Schema, def *ast.Definition, name string) *ast.FieldDefinition {
+	field := def.Fields.ForName(name)
+	if field == nil {
+		return nil
+	}
+	if field.Directives.ForName("lambda") != nil {
+		return field
+	}
+	for _, interfaceName := range def.Interfaces {
+		if it := schema.Types[interfaceName]; it != nil {
+			if inherited := it.Fields.ForName(name); inherited != nil && inherited.Directives.ForName("lambda") != nil {
+				return inherited
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateArguments checks json arguments against the argument definitions of a field.
+func ValidateArguments(schema *ast.Schema, field *ast.FieldDefinition, args map[string]json.RawMessage) error {
+	for name := range args {
+		if field.Arguments.ForName(name) == nil {
+			return errors.Errorf("Unknown argument %s for %s", name, field.Name)
+		}
+	}
+	for _, arg := range field.Arguments {
+		raw, ok := args[arg.Name]
+		if !ok {
+			if arg.Type.NonNull && arg.DefaultValue == nil {
+				return errors.Errorf("Missing required argument %s of type %s", arg.Name, arg.Type.String())
+			}
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return errors.Wrapf(err, "Could not parse argument %s", arg.Name)
+		}
+		if err := ValidateValue(schema, arg.Type, value, arg.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks a decoded json value against a graphql input type.
+func ValidateValue(schema *ast.Schema, t *ast.Type, value interface{}, path string) error {
+	if value == nil {
+		if t.NonNull {
+			return errors.Errorf("%s must not be null", path)
+		}
+		return nil
+	}
+
+	if t.Elem != nil {
+		list, ok := value.([]interface{})
+		if !ok {
+			// A single value is coerced into a list
+			return ValidateValue(schema, t.Elem, value, path)
+		}
+		for i, item := range list {
+			if err := ValidateValue(schema, t.Elem, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	mismatch := errors.Errorf("%s must be of type %s", path, t.NamedType)
+	switch t.NamedType {
+	case "Int":
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return mismatch
+		}
+		// Int is a 32-bit integer like in the GraphQL spec, only Int64 allows larger values
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return errors.Errorf("%s is out of range for Int", path)
+		}
+		return nil
+	case "Int64":
+		// Dgraph accepts Int64 values as numbers and as strings
+		if s, ok := value.(string); ok {
+			if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+				return mismatch
+			}
+			return nil
+		}
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			return mismatch
+		}
+		return nil
+	case "ID":
+		// Input coercion accepts integers as ids
+		if n, ok := value.(float64); ok && n == math.Trunc(n) {
+			return nil
+		}
+		if _, ok := value.(string); !ok {
+			return mismatch
+		}
+		return nil
+	case "Float":
+		if _, ok := value.(float64); !ok {
+			return mismatch
+		}
+		return nil
+	case "String", "DateTime", "Password":
+		if _, ok := value.(string); !ok {
+			return mismatch
+		}
+		return nil
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return mismatch
+		}
+		return nil
+	}
+
+	def := schema.Types[t.NamedType]
+	if def == nil {
+		return errors.Errorf("Unknown type %s of %s", t.NamedType, path)
+	}
+	switch def.Kind {
+	case ast.Enum:
+		s, ok := value.(string)
+		if !ok || def.EnumValues.ForName(s) == nil {
+			return errors.Errorf("%s must be one of the values of %s", path, def.Name)
+		}
+	case ast.InputObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch
+		}
+		for name := range object {
+			if def.Fields.ForName(name) == nil {
+				return errors.Errorf("Unknown field %s.%s", path, name)
+			}
+		}
+		for _, field := range def.Fields {
+			fieldValue, ok := object[field.Name]
+			if !ok {
+				if field.Type.NonNull && field.DefaultValue == nil {
+					return errors.Errorf("Missing required field %s.%s of type %s", path, field.Name, field.Type.String())
+				}
+				continue
+			}
+			if err := ValidateValue(schema, field.Type, fieldValue, path+"."+field.Name); err != nil {
+				return err
+			}
+		}
+	}
+	// Custom scalars accept any value
+	return nil
+}
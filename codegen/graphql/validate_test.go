@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func Test_ValidateArguments(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+enum Color { RED GREEN }
+input AppleInput {
+	price: Int!
+	color: Color
+}
+type Query {
+	apples(input: [AppleInput!]!, name: String): String
+}`})
+	field := schema.Query.Fields.ForName("apples")
+
+	validate := func(args string) error {
+		var raw map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal([]byte(args), &raw))
+		return ValidateArguments(schema, field, raw)
+	}
+
+	assert.NoError(t, validate(`{"input": [{"price": 1, "color": "RED"}]}`))
+	assert.NoError(t, validate(`{"input": {"price": 1}, "name": null}`))
+	assert.EqualError(t, validate(`{}`), "Missing required argument input of type [AppleInput!]!")
+	assert.EqualError(t, validate(`{"input": [{"price": 1.5}]}`), "input[0].price must be of type Int")
+	assert.EqualError(t, validate(`{"input": [{"price": 1, "color": "BLUE"}]}`), "input[0].color must be one of the values of Color")
+	assert.EqualError(t, validate(`{"input": [{"color": "RED"}]}`), "Missing required field input[0].price of type Int!")
+	assert.EqualError(t, validate(`{"input": [], "other": 1}`), "Unknown argument other for apples")
+	assert.EqualError(t, validate(`{"input": [{"price": 2147483648}]}`), "input[0].price is out of range for Int")
+}
+
+func Test_ValidateValue_Scalars(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+scalar Int64
+type Query {
+	get(id: ID, count: Int, total: Int64): String
+}`})
+
+	tests := []struct {
+		typeName string
+		value    string
+		err      string
+	}{
+		{"ID", `"0x1"`, ""},
+		{"ID", `1`, ""},
+		{"ID", `1.5`, "value must be of type ID"},
+		{"ID", `true`, "value must be of type ID"},
+		{"Int", `2147483647`, ""},
+		{"Int", `-2147483649`, "value is out of range for Int"},
+		{"Int", `"1"`, "value must be of type Int"},
+		{"Int64", `9007199254740991`, ""},
+		{"Int64", `"9223372036854775807"`, ""},
+		{"Int64", `"9223372036854775808"`, "value must be of type Int64"},
+		{"Int64", `"1.5"`, "value must be of type Int64"},
+		{"Int64", `1.5`, "value must be of type Int64"},
+	}
+	for _, test := range tests {
+		var value interface{}
+		assert.NoError(t, json.Unmarshal([]byte(test.value), &value))
+		err := ValidateValue(schema, ast.NamedType(test.typeName, nil), value, "value")
+		if test.err == "" {
+			assert.NoError(t, err, "%s %s", test.typeName, test.value)
+		} else {
+			assert.EqualError(t, err, test.err, "%s %s", test.typeName, test.value)
+		}
+	}
+}
+
+func Test_LambdaField(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: SchemaInputs + DirectiveDefs + `
+interface Post {
+	info: String @lambda
+}
+type Comment implements Post {
+	info: String
+	text: String
+}`})
+	comment := schema.Types["Comment"]
+
+	assert.Equal(t, schema.Types["Post"].Fields.ForName("info"), LambdaField(schema, comment, "info"))
+	assert.Nil(t, LambdaField(schema, comment, "text"))
+	assert.Nil(t, LambdaField(schema, comment, "other"))
+}
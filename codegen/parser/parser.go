@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"go/types"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/schartey/dgraph-lambda-go/codegen/graphql"
 	"github.com/schartey/dgraph-lambda-go/internal"
@@ -13,6 +15,7 @@ import (
 )
 
 var middlewareRegex = regexp.MustCompile(`@middleware\(([^)]+)\)`)
+var secretRegex = regexp.MustCompile(`@secret\b`)
 
 type LambdaOnMutateEvent string
 
@@ -25,6 +28,21 @@ const (
 type GoType struct {
 	Autobind bool
 	TypeName *types.TypeName
+	// Object is set for objects, inputs, interfaces and unions
+	Object bool
+	// Interface is set for interfaces and unions, which are never referenced by pointer
+	Interface bool
+}
+
+// TypeRef holds the list and non-null modifiers of a field, argument or return type. Lists have the type of their
+// elements in Elem, so nested lists like [[Float!]] are represented as well.
+type TypeRef struct {
+	NonNull bool
+	Elem    *TypeRef
+}
+
+func (t *TypeRef) IsArray() bool {
+	return t.Elem != nil
 }
 
 type Scalar struct {
@@ -47,8 +65,14 @@ type EnumValue struct {
 
 type Interface struct {
 	*GoType
-	Name        string
-	Description string
+	Name           string
+	Description    string
+	Fields         []*Field
+	LambdaOnMutate []LambdaOnMutateEvent
+	// Union is set for unions, which have members instead of fields
+	Union bool
+	// Types are the members of a union or the types implementing an interface, sorted by name
+	Types []*Parent
 }
 
 type Field struct {
@@ -56,7 +80,8 @@ type Field struct {
 	Name        string
 	Description string
 	Tag         string
-	IsArray     bool
+	Type        *TypeRef
+	Secret      bool
 }
 
 type Model struct {
@@ -70,13 +95,15 @@ type Model struct {
 
 type Argument struct {
 	*GoType
-	Name    string
-	IsArray bool
+	Name        string
+	Description string
+	Type        *TypeRef
+	Secret      bool
 }
 
 type Return struct {
 	*GoType
-	IsArray bool
+	Type *TypeRef
 }
 
 type Query struct {
@@ -104,6 +131,21 @@ type FieldResolver struct {
 	Field      *Field
 	Parent     *Parent
 	Middleware []string
+	// Implementations are the types implementing the interface of an interface field resolver. Dgraph calls the
+	// resolver with their names unless they declare the field with @lambda themselves.
+	Implementations []*Parent
+}
+
+// Webhook is a type or interface with @lambdaOnMutate. Webhooks of interfaces also receive the events
+// of all implementing types.
+type Webhook struct {
+	*GoType
+	Name            string
+	Interface       bool
+	Implementations []string
+	LambdaOnMutate  []LambdaOnMutateEvent
+	AddInput        *Model
+	Patch           *Model
 }
 
 type Tree struct {
@@ -120,9 +162,11 @@ type ModelTree struct {
 }
 
 type ResolverTree struct {
+	// FieldResolvers are keyed by Parent.field
 	FieldResolvers map[string]*FieldResolver
 	Queries        map[string]*Query
 	Mutations      map[string]*Mutation
+	Webhooks       map[string]*Webhook
 }
 
 type Parser struct {
@@ -144,6 +188,7 @@ func NewParser(schema *ast.Schema, packages *internal.Packages, force []string)
 			FieldResolvers: make(map[string]*FieldResolver),
 			Queries:        make(map[string]*Query),
 			Mutations:      make(map[string]*Mutation),
+			Webhooks:       make(map[string]*Webhook),
 		},
 		Middleware: make(map[string]string),
 	},
@@ -156,9 +201,63 @@ func (p *Parser) Parse() (*Tree, error) {
 	for _, schemaType := range p.schema.Types {
 		p.parseType(schemaType, true)
 	}
+	if err := p.checkFieldResolvers(); err != nil {
+		return nil, err
+	}
 	return p.tree, nil
 }
 
+// checkFieldResolvers detects field resolvers that would generate the same resolver method and fields of a type that
+// are field resolvers of several of its interfaces. Implementing types are added to interface field resolvers.
+func (p *Parser) checkFieldResolvers() error {
+	var keys []string
+	for key := range p.tree.ResolverTree.FieldResolvers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	methods := make(map[string]string)
+	for _, key := range keys {
+		fieldResolver := p.tree.ResolverTree.FieldResolvers[key]
+		method := fieldResolver.Parent.Name + "_" + fieldResolver.Field.Name
+		if other, ok := methods[method]; ok {
+			return fmt.Errorf("field resolvers %s and %s both generate the resolver %s", other, key, method)
+		}
+		methods[method] = key
+	}
+
+	inherited := make(map[string]string)
+	for _, key := range keys {
+		fieldResolver := p.tree.ResolverTree.FieldResolvers[key]
+		def := p.schema.Types[fieldResolver.Parent.Name]
+		if def == nil || def.Kind != ast.Interface {
+			continue
+		}
+
+		possibleTypes := append([]*ast.Definition{}, p.schema.GetPossibleTypes(def)...)
+		sort.Slice(possibleTypes, func(i, j int) bool { return possibleTypes[i].Name < possibleTypes[j].Name })
+
+		for _, possibleType := range possibleTypes {
+			goType, err := p.parseType(possibleType, false)
+			if err != nil {
+				return err
+			}
+			fieldResolver.Implementations = append(fieldResolver.Implementations, &Parent{Name: possibleType.Name, GoType: goType})
+
+			implementation := possibleType.Name + "." + fieldResolver.Field.Name
+			if _, ok := p.tree.ResolverTree.FieldResolvers[implementation]; ok {
+				fmt.Printf("Field resolver %s overrides %s of interface %s\n", implementation, key, def.Name)
+				continue
+			}
+			if other, ok := inherited[implementation]; ok {
+				return fmt.Errorf("%s is a field resolver of the interfaces %s and %s, declare it with @lambda on %s", implementation, other, key, possibleType.Name)
+			}
+			inherited[implementation] = key
+		}
+	}
+	return nil
+}
+
 func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType, error) {
 	if mustLambda && !p.hasLambda(schemaType) {
 		return nil, errors.New("type has no lambda field")
@@ -192,19 +291,39 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 		}
 	}
 
+	switch schemaType.Kind {
+	case ast.Object, ast.InputObject:
+		goType.Object = true
+	case ast.Interface, ast.Union:
+		goType.Object = true
+		goType.Interface = true
+	}
+
 	switch schemaType.Kind {
 	case ast.Interface, ast.Union:
 		if it, ok := p.tree.ModelTree.Interfaces[schemaType.Name]; ok {
 			return it.GoType, nil
 		}
 		it := &Interface{
-			Description: schemaType.Description,
-			Name:        schemaType.Name,
-			GoType:      goType,
+			Description:    schemaType.Description,
+			Name:           schemaType.Name,
+			GoType:         goType,
+			LambdaOnMutate: parseLambdaOnMutate(schemaType),
+			Union:          schemaType.Kind == ast.Union,
 		}
 
 		p.tree.ModelTree.Interfaces[it.Name] = it
 
+		possibleTypes := append([]*ast.Definition{}, p.schema.GetPossibleTypes(schemaType)...)
+		sort.Slice(possibleTypes, func(i, j int) bool { return possibleTypes[i].Name < possibleTypes[j].Name })
+		for _, possibleType := range possibleTypes {
+			possibleGoType, err := p.parseType(possibleType, false)
+			if err != nil {
+				return nil, err
+			}
+			it.Types = append(it.Types, &Parent{Name: possibleType.Name, GoType: possibleGoType})
+		}
+
 		for _, field := range schemaType.Fields {
 			fieldType := p.schema.Types[field.Type.Name()]
 
@@ -213,20 +332,17 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 				return nil, err
 			}
 
-			tag := `json:"` + field.Name + `"`
-			if field.Name == "id" {
-				tag += ` dql:"uid"`
-			} else {
-				tag += ` dql:"` + it.Name + "." + field.Name + `"`
-			}
+			tag := `json:"` + field.Name + `" dql:"` + p.predicate(schemaType, field.Name) + `"`
 
 			modelField := &Field{
 				Name:        field.Name,
 				Description: field.Description,
 				Tag:         tag,
 				GoType:      fieldGoType,
-				IsArray:     graphql.IsArray(field.Type.String()),
+				Type:        typeRef(field.Type),
+				Secret:      isSecret(field.Description, field.Type.Name()),
 			}
+			it.Fields = append(it.Fields, modelField)
 
 			lambdaDirective := field.Directives.ForName("lambda")
 
@@ -241,7 +357,13 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 				for _, m := range fieldMiddleware {
 					p.tree.Middleware[m] = m
 				}
-				p.tree.ResolverTree.FieldResolvers[field.Name] = &FieldResolver{Field: modelField, Parent: &Parent{Name: schemaType.Name, GoType: it.GoType}, Middleware: fieldMiddleware}
+				p.tree.ResolverTree.FieldResolvers[schemaType.Name+"."+field.Name] = &FieldResolver{Field: modelField, Parent: &Parent{Name: schemaType.Name, GoType: it.GoType}, Middleware: fieldMiddleware}
+			}
+		}
+
+		if len(it.LambdaOnMutate) > 0 {
+			if err := p.parseWebhook(it.Name, it.GoType, it.LambdaOnMutate, it.Fields, schemaType); err != nil {
+				return nil, err
 			}
 		}
 
@@ -272,8 +394,8 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 					return nil, err
 				}
 				returnField := &Return{
-					GoType:  returnGoType,
-					IsArray: graphql.IsArray(field.Type.String()),
+					GoType: returnGoType,
+					Type:   typeRef(field.Type),
 				}
 
 				var args []*Argument
@@ -285,8 +407,10 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 					}
 
 					args = append(args, &Argument{Name: arg.Name,
-						GoType:  argGoType,
-						IsArray: graphql.IsArray(field.Type.String()),
+						Description: arg.Description,
+						GoType:      argGoType,
+						Type:        typeRef(arg.Type),
+						Secret:      isSecret(arg.Description, arg.Type.Name()),
 					})
 				}
 				out := middlewareRegex.FindAllStringSubmatch(field.Description, -1)
@@ -322,18 +446,7 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 
 			p.tree.ModelTree.Models[it.Name] = it
 
-			lambdaOnMutate := schemaType.Directives.ForName("lambdaOnMutate")
-			if lambdaOnMutate != nil {
-				if lambdaOnMutate.Arguments.ForName("add") != nil {
-					it.LambdaOnMutate = append(it.LambdaOnMutate, ADD)
-				}
-				if lambdaOnMutate.Arguments.ForName("update") != nil {
-					it.LambdaOnMutate = append(it.LambdaOnMutate, UPDATE)
-				}
-				if lambdaOnMutate.Arguments.ForName("delete") != nil {
-					it.LambdaOnMutate = append(it.LambdaOnMutate, DELETE)
-				}
-			}
+			it.LambdaOnMutate = parseLambdaOnMutate(schemaType)
 
 			for _, implementor := range p.schema.GetImplements(schemaType) {
 				interfaceType, err := p.parseType(implementor, false)
@@ -343,6 +456,8 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 				it.Implements = append(it.Implements, interfaceType)
 			}
 
+			dqlType, partial := p.inputType(schemaType)
+
 			for _, field := range schemaType.Fields {
 				fieldType := p.schema.Types[field.Type.Name()]
 
@@ -351,19 +466,19 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 					return nil, err
 				}
 
-				tag := `json:"` + field.Name + `"`
-				if field.Name == "id" {
-					tag += ` dql:"uid"`
-				} else {
-					tag += ` dql:"` + it.Name + "." + field.Name + `"`
+				tag := `json:"` + field.Name
+				if partial && !field.Type.NonNull {
+					tag += `,omitempty`
 				}
+				tag += `" dql:"` + p.predicate(p.schema.Types[dqlType], field.Name) + `"`
 
 				modelField := &Field{
 					Name:        field.Name,
 					Description: field.Description,
 					Tag:         tag,
 					GoType:      fieldGoType,
-					IsArray:     graphql.IsArray(field.Type.String()),
+					Type:        typeRef(field.Type),
+					Secret:      isSecret(field.Description, field.Type.Name()),
 				}
 				it.Fields = append(it.Fields, modelField)
 
@@ -380,7 +495,13 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 					for _, m := range fieldMiddleware {
 						p.tree.Middleware[m] = m
 					}
-					p.tree.ResolverTree.FieldResolvers[field.Name] = &FieldResolver{Field: modelField, Parent: &Parent{Name: schemaType.Name, GoType: it.GoType}, Middleware: fieldMiddleware}
+					p.tree.ResolverTree.FieldResolvers[schemaType.Name+"."+field.Name] = &FieldResolver{Field: modelField, Parent: &Parent{Name: schemaType.Name, GoType: it.GoType}, Middleware: fieldMiddleware}
+				}
+			}
+
+			if len(it.LambdaOnMutate) > 0 {
+				if err := p.parseWebhook(it.Name, it.GoType, it.LambdaOnMutate, it.Fields, schemaType); err != nil {
+					return nil, err
 				}
 			}
 			return it.GoType, nil
@@ -421,6 +542,286 @@ func (p *Parser) parseType(schemaType *ast.Definition, mustLambda bool) (*GoType
 	return nil, nil
 }
 
+// parseWebhook registers the webhook of a type or interface and resolves the Add<Type>Input and <Type>Patch
+// types of its events. If the schema does not contain Dgraph's generated types they are derived from the fields.
+func (p *Parser) parseWebhook(name string, goType *GoType, events []LambdaOnMutateEvent, fields []*Field, schemaType *ast.Definition) error {
+	webhook := &Webhook{
+		GoType:         goType,
+		Name:           name,
+		Interface:      schemaType.Kind == ast.Interface,
+		LambdaOnMutate: events,
+	}
+
+	if webhook.Interface {
+		for _, possibleType := range p.schema.GetPossibleTypes(schemaType) {
+			if possibleType.Name != name {
+				webhook.Implementations = append(webhook.Implementations, possibleType.Name)
+			}
+		}
+	}
+
+	var err error
+	if webhook.AddInput, err = p.parseEventInput(fields, schemaType, "Add"+name+"Input", false); err != nil {
+		return err
+	}
+	if webhook.Patch, err = p.parseEventInput(fields, schemaType, name+"Patch", true); err != nil {
+		return err
+	}
+
+	p.tree.ResolverTree.Webhooks[name] = webhook
+	return nil
+}
+
+func (p *Parser) parseEventInput(fields []*Field, schemaType *ast.Definition, name string, patch bool) (*Model, error) {
+	if inputType, ok := p.schema.Types[name]; ok {
+		if _, err := p.parseType(inputType, false); err != nil {
+			return nil, err
+		}
+		input := p.tree.ModelTree.Models[name]
+		if secret := graphql.SecretField(schemaType); secret != "" {
+			for _, field := range input.Fields {
+				if field.Name == secret {
+					field.Secret = true
+				}
+			}
+		}
+		return input, nil
+	}
+	if input, ok := p.tree.ModelTree.Models[name]; ok {
+		return input, nil
+	}
+
+	input := &Model{
+		Name:   name,
+		GoType: &GoType{TypeName: types.NewTypeName(0, nil, name, nil), Object: true},
+	}
+
+	for i, field := range schemaType.Fields {
+		// Dgraph does not store ids and lambda fields, so they are never part of an input
+		if field.Type.Name() == "ID" || field.Directives.ForName("lambda") != nil || field.Directives.ForName("custom") != nil {
+			continue
+		}
+
+		tag := `json:"` + field.Name
+		if patch {
+			tag += `,omitempty`
+		}
+		tag += `" dql:"` + p.predicate(schemaType, field.Name) + `"`
+
+		fieldType := fields[i].Type
+		if patch {
+			// Patches only contain the changed fields
+			fieldType = &TypeRef{Elem: fieldType.Elem}
+		}
+
+		// Dgraph sends references to other nodes as <Type>Ref
+		goType := fields[i].GoType
+		ref, err := p.parseRef(field.Type.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ref != nil {
+			goType = ref.GoType
+		}
+
+		input.Fields = append(input.Fields, &Field{
+			Name:        field.Name,
+			Description: field.Description,
+			Tag:         tag,
+			GoType:      goType,
+			Type:        fieldType,
+			Secret:      fields[i].Secret,
+		})
+	}
+
+	// The password field of @secret is not part of the type, but of its inputs
+	if secret := graphql.SecretField(schemaType); secret != "" {
+		stringType, err := p.parseType(p.schema.Types["String"], false)
+		if err != nil {
+			return nil, err
+		}
+		tag := `json:"` + secret
+		if patch {
+			tag += `,omitempty`
+		}
+		tag += `" dql:"` + p.predicate(schemaType, secret) + `"`
+		input.Fields = append(input.Fields, &Field{Name: secret, Tag: tag, GoType: stringType, Type: &TypeRef{NonNull: !patch}, Secret: true})
+	}
+
+	p.tree.ModelTree.Models[name] = input
+	return input, nil
+}
+
+// parseRef resolves the <Type>Ref input Dgraph uses for references to objects, interfaces and unions, and returns nil
+// for other types. If the schema does not contain Dgraph's generated type it is derived from the fields. References
+// to interfaces only contain the fields identifying a node, references to unions contain a reference per member.
+func (p *Parser) parseRef(typeName string) (*Model, error) {
+	schemaType := p.schema.Types[typeName]
+	if schemaType == nil || (schemaType.Kind != ast.Object && schemaType.Kind != ast.Interface && schemaType.Kind != ast.Union) {
+		return nil, nil
+	}
+
+	name := typeName + "Ref"
+	if refType, ok := p.schema.Types[name]; ok {
+		if _, err := p.parseType(refType, false); err != nil {
+			return nil, err
+		}
+		return p.tree.ModelTree.Models[name], nil
+	}
+	if ref, ok := p.tree.ModelTree.Models[name]; ok {
+		return ref, nil
+	}
+
+	ref := &Model{
+		Name:   name,
+		GoType: &GoType{TypeName: types.NewTypeName(0, nil, name, nil), Object: true},
+	}
+	// Registered before the fields are parsed, as types can reference each other
+	p.tree.ModelTree.Models[name] = ref
+
+	if schemaType.Kind == ast.Union {
+		for _, member := range schemaType.Types {
+			memberRef, err := p.parseRef(member)
+			if err != nil {
+				return nil, err
+			}
+			fieldName := strings.ToLower(member[:1]) + member[1:] + "Ref"
+			ref.Fields = append(ref.Fields, &Field{Name: fieldName, Tag: `json:"` + fieldName + `,omitempty"`, GoType: memberRef.GoType, Type: &TypeRef{}})
+		}
+		return ref, nil
+	}
+
+	for _, field := range schemaType.Fields {
+		if field.Directives.ForName("lambda") != nil || field.Directives.ForName("custom") != nil {
+			continue
+		}
+		if schemaType.Kind == ast.Interface && field.Type.Name() != "ID" && field.Directives.ForName("id") == nil {
+			continue
+		}
+
+		goType, err := p.parseType(p.schema.Types[field.Type.Name()], false)
+		if err != nil {
+			return nil, err
+		}
+		fieldRef, err := p.parseRef(field.Type.Name())
+		if err != nil {
+			return nil, err
+		}
+		if fieldRef != nil {
+			goType = fieldRef.GoType
+		}
+
+		ref.Fields = append(ref.Fields, &Field{
+			Name:        field.Name,
+			Description: field.Description,
+			Tag:         `json:"` + field.Name + `,omitempty" dql:"` + p.predicate(schemaType, field.Name) + `"`,
+			GoType:      goType,
+			Type:        &TypeRef{Elem: typeRef(field.Type).Elem},
+			Secret:      isSecret(field.Description, field.Type.Name()),
+		})
+	}
+
+	if secret := graphql.SecretField(schemaType); secret != "" && schemaType.Kind == ast.Object {
+		stringType, err := p.parseType(p.schema.Types["String"], false)
+		if err != nil {
+			return nil, err
+		}
+		tag := `json:"` + secret + `,omitempty" dql:"` + p.predicate(schemaType, secret) + `"`
+		ref.Fields = append(ref.Fields, &Field{Name: secret, Tag: tag, GoType: stringType, Type: &TypeRef{}, Secret: true})
+	}
+	return ref, nil
+}
+
+// inputType returns the type whose predicates the fields of a model are stored in. Fields of Dgraph's generated
+// inputs Add<Type>Input, <Type>Patch and <Type>Ref are predicates of <Type>, patches and references only contain some
+// of the fields. The inputs of Dgraph's geo types are not stored as predicates.
+func (p *Parser) inputType(schemaType *ast.Definition) (string, bool) {
+	if schemaType.Kind != ast.InputObject {
+		return schemaType.Name, false
+	}
+	candidates := []struct {
+		prefix, suffix string
+		partial        bool
+	}{{"Add", "Input", false}, {"", "Patch", true}, {"", "Ref", true}}
+	for _, c := range candidates {
+		if !strings.HasPrefix(schemaType.Name, c.prefix) || !strings.HasSuffix(schemaType.Name, c.suffix) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(schemaType.Name, c.prefix), c.suffix)
+		if def := p.schema.Types[name]; def != nil && (def.Kind == ast.Object || def.Kind == ast.Interface) && !graphql.IsDgraphType(name) && name != "PointList" {
+			return name, c.partial
+		}
+	}
+	return schemaType.Name, false
+}
+
+func typeRef(t *ast.Type) *TypeRef {
+	ref := &TypeRef{NonNull: t.NonNull}
+	if t.Elem != nil {
+		ref.Elem = typeRef(t.Elem)
+	}
+	return ref
+}
+
+// isSecret reports whether a field or argument holds sensitive data. These are fields of the Password scalar
+// and fields marked with @secret in their description.
+func isSecret(description string, typeName string) bool {
+	return typeName == "Password" || secretRegex.MatchString(description)
+}
+
+// predicate returns the DQL predicate a field is stored in. Fields of interfaces are stored in the predicates of the
+// interface, and @dgraph(type: ...) and @dgraph(pred: ...) map types and fields onto an existing DQL schema.
+func (p *Parser) predicate(schemaType *ast.Definition, fieldName string) string {
+	if field := schemaType.Fields.ForName(fieldName); field != nil {
+		if field.Type.NamedType == "ID" {
+			return "uid"
+		}
+		if pred := dgraphArgument(field.Directives, "pred"); pred != "" {
+			return pred
+		}
+	}
+	for _, name := range schemaType.Interfaces {
+		if it := p.schema.Types[name]; it != nil && it.Fields.ForName(fieldName) != nil {
+			return p.predicate(it, fieldName)
+		}
+	}
+	if secret := schemaType.Directives.ForName("secret"); secret != nil && graphql.SecretField(schemaType) == fieldName {
+		if arg := secret.Arguments.ForName("pred"); arg != nil {
+			return arg.Value.Raw
+		}
+	}
+
+	typeName := schemaType.Name
+	if name := dgraphArgument(schemaType.Directives, "type"); name != "" {
+		typeName = name
+	}
+	return typeName + "." + fieldName
+}
+
+func dgraphArgument(directives ast.DirectiveList, name string) string {
+	if dgraph := directives.ForName("dgraph"); dgraph != nil {
+		if arg := dgraph.Arguments.ForName(name); arg != nil {
+			return arg.Value.Raw
+		}
+	}
+	return ""
+}
+
+func parseLambdaOnMutate(schemaType *ast.Definition) []LambdaOnMutateEvent {
+	var events []LambdaOnMutateEvent
+
+	lambdaOnMutate := schemaType.Directives.ForName("lambdaOnMutate")
+	if lambdaOnMutate == nil {
+		return events
+	}
+	for _, event := range []LambdaOnMutateEvent{ADD, UPDATE, DELETE} {
+		if arg := lambdaOnMutate.Arguments.ForName(string(event)); arg != nil && arg.Value.Raw == "true" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 func (p *Parser) hasLambda(def *ast.Definition) bool {
 
 	for _, f := range p.force {
@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/codegen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func parseFile(t *testing.T, filename string) *Tree {
+	input, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+
+	schema, gqlErr := gqlparser.LoadSchema(
+		&ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		&ast.Source{Name: filename, Input: string(input)},
+	)
+	require.Nil(t, gqlErr)
+
+	tree, err := NewParser(schema, nil, nil).Parse()
+	require.NoError(t, err)
+	return tree
+}
+
+func Test_Parse_Lists(t *testing.T) {
+	tree := parseFile(t, "../../test_resources/lists.graphql")
+
+	matrix := tree.ModelTree.Models["Matrix"]
+	require.NotNil(t, matrix)
+	assert.Equal(t, &TypeRef{Elem: &TypeRef{Elem: &TypeRef{NonNull: true}}}, matrix.Fields[1].Type)
+	assert.Equal(t, &TypeRef{NonNull: true, Elem: &TypeRef{NonNull: true}}, matrix.Fields[2].Type)
+	assert.Equal(t, &TypeRef{}, matrix.Fields[3].Type)
+
+	getMatrices := tree.ResolverTree.Queries["getMatrices"]
+	require.Len(t, getMatrices.Arguments, 2)
+	assert.Equal(t, &TypeRef{NonNull: true, Elem: &TypeRef{NonNull: true}}, getMatrices.Arguments[0].Type)
+	assert.Equal(t, &TypeRef{Elem: &TypeRef{}}, getMatrices.Arguments[1].Type)
+	assert.Equal(t, &TypeRef{Elem: &TypeRef{NonNull: true}}, getMatrices.Return.Type)
+	assert.True(t, getMatrices.Return.Object)
+
+	multiply := tree.ResolverTree.Queries["multiply"]
+	nested := &TypeRef{NonNull: true, Elem: &TypeRef{NonNull: true, Elem: &TypeRef{NonNull: true}}}
+	assert.Equal(t, nested, multiply.Arguments[0].Type)
+	assert.Equal(t, nested, multiply.Return.Type)
+	assert.False(t, multiply.Return.Object)
+}
+
+func Test_Parse_Unions(t *testing.T) {
+	tree := parseFile(t, "../../test_resources/unions.graphql")
+
+	homeMember := tree.ModelTree.Interfaces["HomeMember"]
+	require.NotNil(t, homeMember)
+	assert.True(t, homeMember.Union)
+	var members []string
+	for _, member := range homeMember.Types {
+		members = append(members, member.Name)
+	}
+	assert.Equal(t, []string{"Cat", "Dog", "Human"}, members)
+
+	animal := tree.ModelTree.Interfaces["Animal"]
+	assert.False(t, animal.Union)
+	assert.Len(t, animal.Types, 2)
+
+	// Members implement the union, Human is generated although it is only referenced by the union
+	human := tree.ModelTree.Models["Human"]
+	require.NotNil(t, human)
+	assert.Equal(t, []*GoType{homeMember.GoType}, human.Implements)
+	assert.Len(t, tree.ModelTree.Models["Dog"].Implements, 2)
+}
+
+func Test_Parse_FieldResolvers(t *testing.T) {
+	tree := parseFile(t, "../../test_resources/field_resolvers.graphql")
+
+	var keys []string
+	for key, fieldResolver := range tree.ResolverTree.FieldResolvers {
+		keys = append(keys, key)
+		assert.Equal(t, key, fieldResolver.Parent.Name+"."+fieldResolver.Field.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"Ranked.score", "Named.name",
+		"User.score", "User.name", "User.rank", "User.status",
+		"Team.name", "Team.rank", "Team.status",
+	}, keys)
+
+	var implementations []string
+	for _, implementation := range tree.ResolverTree.FieldResolvers["Ranked.score"].Implementations {
+		implementations = append(implementations, implementation.Name)
+	}
+	assert.Equal(t, []string{"Team", "User"}, implementations)
+	assert.True(t, tree.ResolverTree.FieldResolvers["Ranked.score"].Parent.Interface)
+}
+
+func Test_Parse_FieldResolvers_Duplicates(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(
+		&ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		&ast.Source{Name: "schema.graphql", Input: `
+			interface Ranked { id: ID!, score: Int @lambda }
+			interface Scored { id: ID!, score: Int @lambda }
+			type User implements Ranked & Scored { id: ID!, score: Int }`,
+		})
+	_, err := NewParser(schema, nil, nil).Parse()
+	assert.EqualError(t, err, "User.score is a field resolver of the interfaces Ranked.score and Scored.score, declare it with @lambda on User")
+
+	schema = gqlparser.MustLoadSchema(
+		&ast.Source{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		&ast.Source{Name: "schema.graphql", Input: `
+			type User_rank { id: ID!, value: Int @lambda }
+			type User { id: ID!, rank_value: Int @lambda }`,
+		})
+	_, err = NewParser(schema, nil, nil).Parse()
+	assert.EqualError(t, err, "field resolvers User.rank_value and User_rank.value both generate the resolver User_rank_value")
+}
+
+func Test_Parse_DgraphDirectives(t *testing.T) {
+	tree := parseFile(t, "../../test_resources/dgraph_directives.graphql")
+
+	tags := make(map[string]string)
+	for _, field := range tree.ModelTree.Models["Person"].Fields {
+		tags[field.Name] = field.Tag
+	}
+	assert.Equal(t, `json:"id" dql:"uid"`, tags["id"])
+	assert.Equal(t, `json:"name" dql:"name"`, tags["name"])
+	assert.Equal(t, `json:"appearsIn" dql:"appears_in"`, tags["appearsIn"])
+	assert.Equal(t, `json:"rating" dql:"Film.Character.rating"`, tags["rating"])
+	assert.Equal(t, `json:"homePlanet" dql:"Film.Person.homePlanet"`, tags["homePlanet"])
+	assert.Equal(t, `json:"friendOf" dql:"~friend"`, tags["friendOf"])
+
+	for _, field := range tree.ModelTree.Interfaces["Character"].Fields {
+		if field.Name == "appearsIn" {
+			assert.Equal(t, `json:"appearsIn" dql:"appears_in"`, field.Tag)
+		}
+	}
+}
+
+func Test_Parse_DgraphTypes(t *testing.T) {
+	input, err := ioutil.ReadFile("../../test_resources/dgraph_types.graphql")
+	require.NoError(t, err)
+	sources := []*ast.Source{
+		{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		{Name: "dgraph_types.graphql", Input: string(input)},
+	}
+	generated, err := graphql.ExpandSchema(sources)
+	require.NoError(t, err)
+	schema, gqlErr := gqlparser.LoadSchema(append(sources, generated)...)
+	require.Nil(t, gqlErr)
+
+	tree, err := NewParser(schema, nil, nil).Parse()
+	require.NoError(t, err)
+
+	importUsers := tree.ResolverTree.Mutations["importUsers"]
+	require.NotNil(t, importUsers)
+	assert.Equal(t, "AddUserInput", importUsers.Arguments[0].TypeName.Name())
+
+	// Fields of generated inputs are predicates of their type
+	tags := make(map[string]string)
+	for _, field := range tree.ModelTree.Models["AddUserInput"].Fields {
+		tags[field.Name] = field.Tag
+	}
+	assert.Equal(t, `json:"username" dql:"User.username"`, tags["username"])
+	for _, field := range tree.ModelTree.Models["UserPatch"].Fields {
+		if field.Name == "name" {
+			assert.Equal(t, `json:"name,omitempty" dql:"User.name"`, field.Tag)
+		}
+	}
+	assert.NotNil(t, tree.ModelTree.Models["UserFilter"])
+	assert.NotNil(t, tree.ModelTree.Models["PostRef"])
+	assert.NotNil(t, tree.ModelTree.Enums["UserOrderable"])
+}
+
+func Test_Parse_WebhookRefs(t *testing.T) {
+	tree := parseFile(t, "../../test_resources/webhooks.graphql")
+
+	// References in synthesized inputs are Dgraph's <Type>Ref
+	types := make(map[string]string)
+	for _, field := range tree.ModelTree.Models["AddAuthorInput"].Fields {
+		types[field.Name] = field.TypeName.Name()
+	}
+	assert.Equal(t, "PostRef", types["posts"])
+	assert.Equal(t, "AuthorRef", types["bestFriend"])
+	assert.Equal(t, "HomeMemberRef", types["owner"])
+	for _, field := range tree.ModelTree.Models["AuthorPatch"].Fields {
+		if field.Name == "bestFriend" {
+			assert.Equal(t, "AuthorRef", field.TypeName.Name())
+		}
+	}
+
+	var fields []string
+	for _, field := range tree.ModelTree.Models["PostRef"].Fields {
+		fields = append(fields, field.Name)
+	}
+	assert.Equal(t, []string{"id"}, fields)
+
+	fields = nil
+	for _, field := range tree.ModelTree.Models["HomeMemberRef"].Fields {
+		fields = append(fields, field.Name+":"+field.TypeName.Name())
+	}
+	assert.Equal(t, []string{"humanRef:HumanRef", "dogRef:DogRef"}, fields)
+
+	author := tree.ModelTree.Models["AuthorRef"]
+	require.NotNil(t, author)
+	assert.Equal(t, `json:"id,omitempty" dql:"uid"`, author.Fields[0].Tag)
+	assert.Equal(t, &TypeRef{}, author.Fields[1].Type)
+}
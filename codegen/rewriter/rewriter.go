@@ -82,11 +82,13 @@ func (r *Rewriter) Load() error {
 				if strings.HasPrefix(d.Name.Name, "Webhook_") {
 					webhookName := strings.TrimPrefix(d.Name.Name, "Webhook_")
 
-					for _, model := range r.parsedTree.ModelTree.Models {
-						if model.TypeName.Name() == webhookName {
-							_, r.RewriteBodies[d.Name.Name] = r.config.Packages.GetSource(pkg, d.Body.Pos()+1, d.Body.End()-1)
-							found = true
-							break
+					for _, webhook := range r.parsedTree.ResolverTree.Webhooks {
+						for _, event := range webhook.LambdaOnMutate {
+							if webhook.Name+"_"+string(event) == webhookName {
+								_, r.RewriteBodies[d.Name.Name] = r.config.Packages.GetSource(pkg, d.Body.Pos()+1, d.Body.End()-1)
+								found = true
+								break
+							}
 						}
 					}
 				}
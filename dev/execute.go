@@ -0,0 +1,377 @@
+package dev
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/codegen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func (e *execution) root(def *ast.Definition, set ast.SelectionSet) interface{} {
+	data := newOrderedMap()
+	for _, field := range e.collect(set, def) {
+		path := ast.Path{ast.PathName(field.Alias)}
+		if field.Name == "__typename" {
+			data.set(field.Alias, def.Name)
+			continue
+		}
+
+		var value interface{}
+		switch {
+		case graphql.LambdaField(e.server.Schema, def, field.Name) != nil:
+			res, err := e.resolve(e.request(def.Name, field, nil))
+			if err != nil {
+				e.fail(path, "%s", err.Error())
+			}
+			value = res
+		case def == e.server.Schema.Query && strings.HasPrefix(field.Name, "get"):
+			typeName := strings.TrimPrefix(field.Name, "get")
+			id, _ := field.ArgumentMap(e.vars)["id"].(string)
+			if object := e.server.SeedData.Get(typeName, id); object != nil {
+				value = object
+			}
+		case def == e.server.Schema.Query && strings.HasPrefix(field.Name, "query"):
+			value = e.all(strings.TrimPrefix(field.Name, "query"))
+		default:
+			e.fail(path, "%s.%s is not a @lambda field and not supported by the emulator", def.Name, field.Name)
+		}
+
+		data.set(field.Alias, e.complete(field, []interface{}{value}, path)[0])
+	}
+	return data
+}
+
+// all returns all seeded objects of a type, or of all implementing types of an interface.
+func (e *execution) all(typeName string) []interface{} {
+	var objects []interface{}
+	def := e.server.Schema.Types[typeName]
+	if def == nil {
+		return objects
+	}
+	for _, possible := range e.server.Schema.GetPossibleTypes(def) {
+		for _, object := range e.server.SeedData[possible.Name] {
+			objects = append(objects, withTypeName(object, possible.Name))
+		}
+	}
+	return objects
+}
+
+func withTypeName(object map[string]interface{}, typeName string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(object)+1)
+	for k, v := range object {
+		copied[k] = v
+	}
+	if _, ok := copied["__typename"]; !ok {
+		copied["__typename"] = typeName
+	}
+	return copied
+}
+
+// complete resolves the selection set of a field for the values of all parents at once, so field resolvers are
+// called once per field like Dgraph does.
+func (e *execution) complete(field *ast.Field, values []interface{}, path ast.Path) []interface{} {
+	if len(field.SelectionSet) == 0 {
+		return values
+	}
+	typeName := field.Definition.Type.Name()
+
+	type position struct{ value, item int }
+	var objects []map[string]interface{}
+	var positions []position
+
+	object := func(v interface{}) map[string]interface{} {
+		switch o := v.(type) {
+		case map[string]interface{}:
+			return o
+		case string:
+			// References in seed data can be ids
+			return map[string]interface{}{"id": o}
+		}
+		return nil
+	}
+
+	results := make([]interface{}, len(values))
+	for i, v := range values {
+		if list, ok := v.([]interface{}); ok {
+			items := make([]interface{}, len(list))
+			for j, item := range list {
+				if o := object(item); o != nil {
+					objects = append(objects, o)
+					positions = append(positions, position{i, j})
+				}
+			}
+			results[i] = items
+		} else if o := object(v); o != nil {
+			objects = append(objects, o)
+			positions = append(positions, position{i, -1})
+		}
+	}
+
+	resolved := e.objects(typeName, field.SelectionSet, objects, path)
+	for k, p := range positions {
+		if p.item < 0 {
+			results[p.value] = resolved[k]
+		} else {
+			results[p.value].([]interface{})[p.item] = resolved[k]
+		}
+	}
+	return results
+}
+
+// objects resolves a selection set on objects of a type. Objects of interfaces and unions are grouped by their
+// concrete type first.
+func (e *execution) objects(typeName string, set ast.SelectionSet, objects []map[string]interface{}, path ast.Path) []interface{} {
+	results := make([]interface{}, len(objects))
+	def := e.server.Schema.Types[typeName]
+
+	if def.Kind == ast.Interface || def.Kind == ast.Union {
+		groups := make(map[string][]int)
+		var order []string
+		for i, object := range objects {
+			concrete := e.concreteType(def, object)
+			if concrete == "" {
+				e.fail(path, "Could not determine the type of %v, add __typename to the seed data or lambda result", object["id"])
+				continue
+			}
+			if _, ok := groups[concrete]; !ok {
+				order = append(order, concrete)
+			}
+			groups[concrete] = append(groups[concrete], i)
+		}
+		for _, concrete := range order {
+			var group []map[string]interface{}
+			for _, i := range groups[concrete] {
+				group = append(group, objects[i])
+			}
+			for k, result := range e.objects(concrete, set, group, path) {
+				results[groups[concrete][k]] = result
+			}
+		}
+		return results
+	}
+
+	// Stored fields of the object are read from the seed data, values of the result take precedence
+	parents := make([]map[string]interface{}, len(objects))
+	for i, object := range objects {
+		parents[i] = object
+		if id, ok := object["id"].(string); ok {
+			if seeded := e.server.SeedData.Get(typeName, id); seeded != nil {
+				merged := make(map[string]interface{})
+				for k, v := range seeded {
+					merged[k] = v
+				}
+				for k, v := range object {
+					merged[k] = v
+				}
+				parents[i] = merged
+			}
+		}
+	}
+
+	maps := make([]*orderedMap, len(objects))
+	for i := range maps {
+		maps[i] = newOrderedMap()
+		results[i] = maps[i]
+	}
+
+	for _, field := range e.collect(set, def) {
+		fieldPath := append(append(ast.Path{}, path...), ast.PathName(field.Alias))
+		if field.Name == "__typename" {
+			for _, m := range maps {
+				m.set(field.Alias, typeName)
+			}
+			continue
+		}
+
+		values := make([]interface{}, len(parents))
+		// Fields inherited from an interface @lambda are resolved by the lambda as well
+		if graphql.LambdaField(e.server.Schema, def, field.Name) != nil {
+			values = e.fieldResolver(typeName, field, parents, fieldPath)
+		} else {
+			for i, parent := range parents {
+				values[i] = parent[field.Name]
+			}
+		}
+
+		values = e.complete(field, values, fieldPath)
+		for i, m := range maps {
+			m.set(field.Alias, values[i])
+		}
+	}
+	return results
+}
+
+func (e *execution) concreteType(def *ast.Definition, object map[string]interface{}) string {
+	if typeName, ok := object["__typename"].(string); ok {
+		return typeName
+	}
+	possible := e.server.Schema.GetPossibleTypes(def)
+	if len(possible) == 1 {
+		return possible[0].Name
+	}
+	if id, ok := object["id"].(string); ok {
+		for _, p := range possible {
+			if e.server.SeedData.Get(p.Name, id) != nil {
+				return p.Name
+			}
+		}
+	}
+	return ""
+}
+
+func (e *execution) fieldResolver(typeName string, field *ast.Field, parents []map[string]interface{}, path ast.Path) []interface{} {
+	values := make([]interface{}, len(parents))
+	if len(parents) == 0 {
+		return values
+	}
+
+	res, err := e.resolve(e.request(typeName, field, parents))
+	if err != nil {
+		e.fail(path, "%s", err.Error())
+		return values
+	}
+	list, ok := res.([]interface{})
+	if !ok || len(list) != len(parents) {
+		e.fail(path, "%s.%s must return a list with one value per parent", typeName, field.Name)
+		return values
+	}
+	return list
+}
+
+// request builds the request Dgraph sends for a lambda field.
+func (e *execution) request(typeName string, field *ast.Field, parents []map[string]interface{}) *api.Request {
+	request := &api.Request{
+		Resolver:    typeName + "." + field.Name,
+		AccessToken: e.accessToken,
+		AuthHeader:  e.authHeader,
+		Field:       api.InfoField{Field: e.selectionField(field)},
+		Args:        make(map[string]json.RawMessage),
+	}
+	for name, value := range field.ArgumentMap(e.vars) {
+		request.Args[name], _ = json.Marshal(value)
+	}
+	if parents != nil {
+		request.Parents, _ = json.Marshal(parents)
+	}
+	return request
+}
+
+func (e *execution) selectionField(field *ast.Field) api.SelectionField {
+	selection := api.SelectionField{
+		Alias:     field.Alias,
+		Name:      field.Name,
+		Arguments: make(map[string]json.RawMessage),
+	}
+	if field.Definition != nil {
+		for name, value := range field.ArgumentMap(e.vars) {
+			selection.Arguments[name], _ = json.Marshal(value)
+		}
+	}
+	for _, directive := range field.Directives {
+		d := api.Directive{Name: directive.Name, Arguments: make(map[string]json.RawMessage)}
+		for _, arg := range directive.Arguments {
+			value, _ := arg.Value.Value(e.vars)
+			d.Arguments[arg.Name], _ = json.Marshal(value)
+		}
+		selection.Directives = append(selection.Directives, d)
+	}
+	var sub func(set ast.SelectionSet)
+	sub = func(set ast.SelectionSet) {
+		for _, s := range set {
+			switch s := s.(type) {
+			case *ast.Field:
+				selection.SelectionSet = append(selection.SelectionSet, e.selectionField(s))
+			case *ast.InlineFragment:
+				sub(s.SelectionSet)
+			case *ast.FragmentSpread:
+				sub(s.Definition.SelectionSet)
+			}
+		}
+	}
+	sub(field.SelectionSet)
+	return selection
+}
+
+func (e *execution) resolve(request *api.Request) (interface{}, error) {
+	res, err := e.server.Executer.Resolve(e.ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	var value interface{}
+	if jsonErr := json.Unmarshal(res, &value); jsonErr != nil {
+		return nil, jsonErr
+	}
+	return value, nil
+}
+
+// collect returns the fields of a selection set that apply to a type. Fields with the same alias are merged.
+func (e *execution) collect(set ast.SelectionSet, def *ast.Definition) []*ast.Field {
+	var fields []*ast.Field
+	byAlias := make(map[string]*ast.Field)
+
+	var walk func(set ast.SelectionSet)
+	walk = func(set ast.SelectionSet) {
+		for _, s := range set {
+			switch s := s.(type) {
+			case *ast.Field:
+				if e.skip(s.Directives) {
+					continue
+				}
+				if existing, ok := byAlias[s.Alias]; ok {
+					merged := *existing
+					merged.SelectionSet = append(append(ast.SelectionSet{}, existing.SelectionSet...), s.SelectionSet...)
+					*existing = merged
+					continue
+				}
+				copied := *s
+				byAlias[s.Alias] = &copied
+				fields = append(fields, &copied)
+			case *ast.InlineFragment:
+				if !e.skip(s.Directives) && e.applies(s.TypeCondition, def) {
+					walk(s.SelectionSet)
+				}
+			case *ast.FragmentSpread:
+				if !e.skip(s.Directives) && e.applies(s.Definition.TypeCondition, def) {
+					walk(s.Definition.SelectionSet)
+				}
+			}
+		}
+	}
+	walk(set)
+	return fields
+}
+
+func (e *execution) skip(directives ast.DirectiveList) bool {
+	if d := directives.ForName("skip"); d != nil {
+		if value, _ := d.ArgumentMap(e.vars)["if"].(bool); value {
+			return true
+		}
+	}
+	if d := directives.ForName("include"); d != nil {
+		if value, _ := d.ArgumentMap(e.vars)["if"].(bool); !value {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *execution) applies(typeCondition string, def *ast.Definition) bool {
+	if typeCondition == "" || typeCondition == def.Name {
+		return true
+	}
+	condition := e.server.Schema.Types[typeCondition]
+	if condition == nil {
+		return false
+	}
+	for _, possible := range e.server.Schema.GetPossibleTypes(condition) {
+		if possible.Name == def.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package dev
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// SeedData holds the objects of every type by type name. References to other objects are either objects with an id
+// or the id itself.
+type SeedData map[string][]map[string]interface{}
+
+func LoadSeedData(filename string) (SeedData, error) {
+	seedData := make(SeedData)
+
+	b, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return seedData, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read seed data")
+	}
+	if err := json.Unmarshal(b, &seedData); err != nil {
+		return nil, errors.Wrap(err, "Could not parse seed data")
+	}
+	return seedData, nil
+}
+
+// Get returns the seeded object of a type with the given id.
+func (f SeedData) Get(typeName string, id string) map[string]interface{} {
+	for _, object := range f[typeName] {
+		if objectID, ok := object["id"].(string); ok && objectID == id {
+			return object
+		}
+	}
+	return nil
+}
+
+// LoadSchema loads the schema like Dgraph would serve it. For every type a get<Type>(id) and query<Type> query are
+// added, which read from the seed data.
+func LoadSchema(sources []*ast.Source) (*ast.Schema, error) {
+	schema, gqlErr := gqlparser.LoadSchema(sources...)
+	if gqlErr != nil {
+		return nil, gqlErr
+	}
+
+	var names []string
+	for name, def := range schema.Types {
+		// Only types of named sources, the Dgraph definitions are loaded without a name
+		if def.Position == nil || def.Position.Src == nil || def.Position.Src.Name == "" || def.BuiltIn {
+			continue
+		}
+		if (def.Kind == ast.Object || def.Kind == ast.Interface) && def != schema.Query && def != schema.Mutation && def != schema.Subscription {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var queries []string
+	for _, name := range names {
+		def := schema.Types[name]
+		if id := def.Fields.ForName("id"); id != nil && id.Type.Name() == "ID" && (schema.Query == nil || schema.Query.Fields.ForName("get"+name) == nil) {
+			queries = append(queries, "get"+name+"(id: ID!): "+name)
+		}
+		if schema.Query == nil || schema.Query.Fields.ForName("query"+name) == nil {
+			queries = append(queries, "query"+name+": ["+name+"]")
+		}
+	}
+	if len(queries) == 0 {
+		return schema, nil
+	}
+
+	query := "type Query {\n"
+	if schema.Query != nil {
+		query = "extend " + query
+	}
+	extension := &ast.Source{Name: "emulator", Input: query + strings.Join(queries, "\n") + "\n}\n"}
+
+	schema, gqlErr = gqlparser.LoadSchema(append(sources, extension)...)
+	if gqlErr != nil {
+		return nil, gqlErr
+	}
+	return schema, nil
+}
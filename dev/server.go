@@ -0,0 +1,151 @@
+// Package dev emulates the GraphQL endpoint of Dgraph for lambda development. Only @lambda queries, mutations and
+// fields are executed by calling the lambda server, all other data is read from seed data.
+package dev
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+type Server struct {
+	Schema   *ast.Schema
+	SeedData SeedData
+	Executer api.ExecuterInterface
+	// AuthHeader is the header forwarded to the lambda as auth header, like Dgraph.Authorization
+	AuthHeader string
+}
+
+func NewServer(schema *ast.Schema, seedData SeedData, executer api.ExecuterInterface) *Server {
+	return &Server{Schema: schema, SeedData: seedData, Executer: executer, AuthHeader: "Authorization"}
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the GraphQL response of the emulator.
+type Response struct {
+	Data   interface{}   `json:"data"`
+	Errors gqlerror.List `json:"errors,omitempty"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request graphqlRequest
+	if r.Method == http.MethodGet {
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			json.Unmarshal([]byte(variables), &request.Variables)
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, &Response{Errors: gqlerror.List{gqlerror.Errorf("Could not parse request: %s", err.Error())}})
+		return
+	}
+
+	authHeader := api.AuthHeader{Key: s.AuthHeader, Value: r.Header.Get(s.AuthHeader)}
+	response := s.Execute(r.Context(), request.Query, request.OperationName, request.Variables, authHeader, r.Header.Get("X-Dgraph-AccessToken"))
+	writeResponse(w, http.StatusOK, response)
+}
+
+func writeResponse(w http.ResponseWriter, status int, response *Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Execute runs a GraphQL operation.
+func (s *Server) Execute(ctx context.Context, query string, operationName string, variables map[string]interface{}, authHeader api.AuthHeader, accessToken string) *Response {
+	doc, errs := gqlparser.LoadQuery(s.Schema, query)
+	if errs != nil {
+		return &Response{Errors: errs}
+	}
+	op := doc.Operations.ForName(operationName)
+	if operationName == "" && len(doc.Operations) == 1 {
+		// Clients omit the name of the only operation, even if it is named
+		op = doc.Operations[0]
+	}
+	if op == nil {
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("Could not find operation %s", operationName)}}
+	}
+	vars, gqlErr := validator.VariableValues(s.Schema, op, variables)
+	if gqlErr != nil {
+		return &Response{Errors: gqlerror.List{gqlErr}}
+	}
+
+	e := &execution{server: s, ctx: ctx, vars: vars, authHeader: authHeader, accessToken: accessToken}
+
+	var root *ast.Definition
+	switch op.Operation {
+	case ast.Query:
+		root = s.Schema.Query
+	case ast.Mutation:
+		root = s.Schema.Mutation
+	default:
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("Subscriptions are not supported")}}
+	}
+
+	data := e.root(root, op.SelectionSet)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+type execution struct {
+	server      *Server
+	ctx         context.Context
+	vars        map[string]interface{}
+	authHeader  api.AuthHeader
+	accessToken string
+	errors      gqlerror.List
+}
+
+func (e *execution) fail(path ast.Path, format string, args ...interface{}) {
+	err := gqlerror.Errorf(format, args...)
+	err.Path = path
+	e.errors = append(e.errors, err)
+}
+
+// orderedMap keeps the fields of a result in the order of the selection set.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+package dev
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/codegen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type recordingExecuter struct {
+	requests []*api.Request
+}
+
+func (r *recordingExecuter) Resolve(ctx context.Context, request *api.Request) ([]byte, *api.LambdaError) {
+	r.requests = append(r.requests, request)
+	switch request.Resolver {
+	case "Query.hotelsByCity":
+		return []byte(`[{"id":"0x1"},{"id":"0x2","name":"Lambda"}]`), nil
+	case "Hotel.rating":
+		var parents []map[string]interface{}
+		json.Unmarshal(request.Parents, &parents)
+		ratings := make([]interface{}, len(parents))
+		for i := range parents {
+			ratings[i] = i + 1
+		}
+		b, _ := json.Marshal(ratings)
+		return b, nil
+	case "Question.additionalInfo":
+		return []byte(`["Lambda"]`), nil
+	}
+	return []byte(`null`), nil
+}
+
+func Test_Execute(t *testing.T) {
+	schema, err := LoadSchema([]*ast.Source{
+		{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		{Name: "schema.graphql", Input: `
+			type Hotel {
+				id: ID!
+				name: String
+				rating: Int @lambda
+			}
+			type Query {
+				hotelsByCity(city: String!): [Hotel] @lambda
+			}`},
+	})
+	require.NoError(t, err)
+
+	seedData := SeedData{"Hotel": {{"id": "0x1", "name": "Fixture"}, {"id": "0x2", "name": "Stored"}}}
+	executer := &recordingExecuter{}
+	server := NewServer(schema, seedData, executer)
+
+	response := server.Execute(context.Background(), `query($city: String!) { hotelsByCity(city: $city) { id name rating } }`, "", map[string]interface{}{"city": "Vienna"}, api.AuthHeader{Key: "Authorization", Value: "token"}, "")
+	require.Empty(t, response.Errors)
+
+	b, err := json.Marshal(response.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hotelsByCity":[{"id":"0x1","name":"Fixture","rating":1},{"id":"0x2","name":"Lambda","rating":2}]}`, string(b))
+
+	require.Len(t, executer.requests, 2)
+	assert.Equal(t, `"Vienna"`, string(executer.requests[0].Args["city"]))
+	assert.Equal(t, "token", executer.requests[0].AuthHeader.Value)
+	assert.Equal(t, "hotelsByCity", executer.requests[0].Field.Field.Name)
+	assert.Len(t, executer.requests[0].Field.Field.SelectionSet, 3)
+	assert.JSONEq(t, `[{"id":"0x1","name":"Fixture"},{"id":"0x2","name":"Lambda"}]`, string(executer.requests[1].Parents))
+
+	response = server.Execute(context.Background(), `{ getHotel(id: "0x2") { name } queryHotel { id } }`, "", nil, api.AuthHeader{}, "")
+	require.Empty(t, response.Errors)
+	b, _ = json.Marshal(response.Data)
+	assert.JSONEq(t, `{"getHotel":{"name":"Stored"},"queryHotel":[{"id":"0x1"},{"id":"0x2"}]}`, string(b))
+
+	response = server.Execute(context.Background(), `query GetHotel { getHotel(id: "0x1") { name } }`, "", nil, api.AuthHeader{}, "")
+	require.Empty(t, response.Errors)
+	b, _ = json.Marshal(response.Data)
+	assert.JSONEq(t, `{"getHotel":{"name":"Fixture"}}`, string(b))
+}
+
+func Test_Execute_Interface_Lambda(t *testing.T) {
+	schema, err := LoadSchema([]*ast.Source{
+		{Input: graphql.SchemaInputs + graphql.DirectiveDefs},
+		{Name: "schema.graphql", Input: `
+			interface Post {
+				id: ID!
+				additionalInfo: String @lambda
+			}
+			type Question implements Post {
+				id: ID!
+				additionalInfo: String
+			}`},
+	})
+	require.NoError(t, err)
+
+	seedData := SeedData{"Question": {{"id": "0x1", "additionalInfo": "Seeded"}}}
+	executer := &recordingExecuter{}
+	server := NewServer(schema, seedData, executer)
+
+	response := server.Execute(context.Background(), `{ queryQuestion { id additionalInfo } }`, "", nil, api.AuthHeader{}, "")
+	require.Empty(t, response.Errors)
+	b, _ := json.Marshal(response.Data)
+	assert.JSONEq(t, `{"queryQuestion":[{"id":"0x1","additionalInfo":"Lambda"}]}`, string(b))
+
+	require.Len(t, executer.requests, 1)
+	assert.Equal(t, "Question.additionalInfo", executer.requests[0].Resolver)
+}
@@ -19,15 +19,55 @@ type Executer struct {
 	mutationResolver   resolvers.MutationResolver
 	middlewareResolver resolvers.MiddlewareResolver
 	webhookResolver    resolvers.WebhookResolver
+	sinks              *api.SinkRouter
+	eventBus           *api.EventBus
+	logger             api.Logger
 }
 
 func NewExecuter(resolver *resolvers.Resolver) api.ExecuterInterface {
-	return Executer{fieldResolver: resolvers.FieldResolver{Resolver: resolver}, queryResolver: resolvers.QueryResolver{Resolver: resolver}, mutationResolver: resolvers.MutationResolver{Resolver: resolver}, middlewareResolver: resolvers.MiddlewareResolver{Resolver: resolver}, webhookResolver: resolvers.WebhookResolver{Resolver: resolver}}
+	return NewExecuterWithOptions(resolver, api.ExecuterOptions{})
+}
+
+func NewExecuterWithEventBus(resolver *resolvers.Resolver, eventBus *api.EventBus) api.ExecuterInterface {
+	return NewExecuterWithOptions(resolver, api.ExecuterOptions{EventBus: eventBus})
+}
+
+func NewExecuterWithOptions(resolver *resolvers.Resolver, options api.ExecuterOptions) api.ExecuterInterface {
+	if options.Sinks == nil {
+		options.Sinks = newSinkRouter()
+	}
+	if options.EventBus == nil {
+		options.EventBus = api.DefaultEventBus
+	}
+	if options.Logger == nil {
+		options.Logger = api.DefaultLogger
+	}
+	return Executer{fieldResolver: resolvers.FieldResolver{Resolver: resolver}, queryResolver: resolvers.QueryResolver{Resolver: resolver}, mutationResolver: resolvers.MutationResolver{Resolver: resolver}, middlewareResolver: resolvers.MiddlewareResolver{Resolver: resolver}, webhookResolver: resolvers.WebhookResolver{Resolver: resolver}, sinks: options.Sinks, eventBus: options.EventBus, logger: options.Logger}
+}
+
+func newSinkRouter() *api.SinkRouter {
+	router := api.NewSinkRouter()
+	return router
+}
+
+func (e Executer) Redactions() []string {
+	return []string{"Comment.author.pwd", "Post.author.pwd", "Question.author.pwd"}
 }
 
 func (e Executer) Resolve(ctx context.Context, request *api.Request) (response []byte, err *api.LambdaError) {
 	if request.Resolver == "$webhook" {
-		return nil, e.resolveWebhook(ctx, request)
+		if err = e.resolveWebhook(ctx, request); err != nil {
+			return nil, err
+		}
+		// Subscribers and sinks only receive handled events of enabled operations. They must not fail the webhook,
+		// or its retry would run the resolvers and all of them again.
+		if underlyingError := e.eventBus.Publish(ctx, request.Event); underlyingError != nil {
+			e.logger.Printf("Could not publish %s.%s to subscribers: %s", request.Event.TypeName, request.Event.Operation, underlyingError.Error())
+		}
+		if underlyingError := e.sinks.Publish(ctx, request.Event); underlyingError != nil {
+			e.logger.Printf("Could not publish %s.%s to sinks: %s", request.Event.TypeName, request.Event.Operation, underlyingError.Error())
+		}
+		return nil, nil
 	} else {
 		parentsBytes, underlyingError := request.Parents.MarshalJSON()
 		if underlyingError != nil {
@@ -94,12 +134,12 @@ func (e Executer) middleware(mc *api.MiddlewareContext) (err *api.LambdaError) {
 
 func (e Executer) resolveField(ctx context.Context, request *api.Request, parentsBytes []byte) (response []byte, err *api.LambdaError) {
 	switch request.Resolver {
-	case "User.active":
+	case "Figure.size":
 		{
-			var parents []*model.User
+			var parents []*model.Figure
 			json.Unmarshal(parentsBytes, &parents)
 
-			result, err := e.fieldResolver.User_active(ctx, parents, request.AuthHeader)
+			result, err := e.fieldResolver.Figure_size(ctx, parents, request.AuthHeader)
 			if err != nil {
 				return nil, err
 			}
@@ -113,10 +153,12 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 			}
 			break
 		}
-	case "Post.additionalInfo":
+	case "Post.additionalInfo", "Comment.additionalInfo", "Question.additionalInfo":
 		{
-			var parents []*model.Post
-			json.Unmarshal(parentsBytes, &parents)
+			parents, err := decodePostParents(request.Resolver, parentsBytes)
+			if err != nil {
+				return nil, err
+			}
 
 			result, err := e.fieldResolver.Post_additionalInfo(ctx, parents, request.AuthHeader)
 			if err != nil {
@@ -132,12 +174,12 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 			}
 			break
 		}
-	case "User.rank":
+	case "User.active":
 		{
 			var parents []*model.User
 			json.Unmarshal(parentsBytes, &parents)
 
-			result, err := e.fieldResolver.User_rank(ctx, parents, request.AuthHeader)
+			result, err := e.fieldResolver.User_active(ctx, parents, request.AuthHeader)
 			if err != nil {
 				return nil, err
 			}
@@ -151,12 +193,12 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 			}
 			break
 		}
-	case "User.reputation":
+	case "User.rank":
 		{
 			var parents []*model.User
 			json.Unmarshal(parentsBytes, &parents)
 
-			result, err := e.fieldResolver.User_reputation(ctx, parents, request.AuthHeader)
+			result, err := e.fieldResolver.User_rank(ctx, parents, request.AuthHeader)
 			if err != nil {
 				return nil, err
 			}
@@ -170,12 +212,12 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 			}
 			break
 		}
-	case "Figure.size":
+	case "User.reputation":
 		{
-			var parents []*model.Figure
+			var parents []*model.User
 			json.Unmarshal(parentsBytes, &parents)
 
-			result, err := e.fieldResolver.Figure_size(ctx, parents, request.AuthHeader)
+			result, err := e.fieldResolver.User_reputation(ctx, parents, request.AuthHeader)
 			if err != nil {
 				return nil, err
 			}
@@ -194,6 +236,26 @@ func (e Executer) resolveField(ctx context.Context, request *api.Request, parent
 	return nil, &api.LambdaError{Underlying: errors.New("could not find query resolver"), Status: http.StatusNotFound}
 }
 
+// decodePostParents decodes parents into the types implementing Post. The type is taken from the resolver
+// name, or from the __typename or dgraph.type of each parent if the resolver is called with Post.
+func decodePostParents(resolver string, parentsBytes []byte) ([]model.Post, *api.LambdaError) {
+	var rawParents []json.RawMessage
+	if underlyingError := json.Unmarshal(parentsBytes, &rawParents); underlyingError != nil {
+		return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+	}
+
+	resolverTypeName := strings.Split(resolver, ".")[0]
+	parents := make([]model.Post, len(rawParents))
+	for i, rawParent := range rawParents {
+		parent, underlyingError := model.UnmarshalPost(rawParent, resolverTypeName)
+		if underlyingError != nil {
+			return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+		}
+		parents[i] = parent
+	}
+	return parents, nil
+}
+
 func (e Executer) resolveQuery(ctx context.Context, request *api.Request) (response []byte, err *api.LambdaError) {
 	switch request.Resolver {
 	case "Query.getApples":
@@ -212,6 +274,33 @@ func (e Executer) resolveQuery(ctx context.Context, request *api.Request) (respo
 			}
 			break
 		}
+	case "Query.getHomeMembers":
+		{
+			var homeId string
+			json.Unmarshal(request.Args["homeId"], &homeId)
+			result, err := e.queryResolver.Query_getHomeMembers(ctx, homeId, request.AuthHeader)
+			if err != nil {
+				return nil, err
+			}
+
+			var underlyingError error
+			var raw []json.RawMessage
+			if result != nil {
+				raw = make([]json.RawMessage, len(result))
+				for i0 := range result {
+					if raw[i0], underlyingError = model.MarshalHomeMember(result[i0]); underlyingError != nil {
+						return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}
+					}
+				}
+			}
+			response, underlyingError = json.Marshal(raw)
+			if underlyingError != nil {
+				return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}
+			} else {
+				return response, nil
+			}
+			break
+		}
 	case "Query.getHotelByName":
 		{
 			var name string
@@ -221,6 +310,24 @@ func (e Executer) resolveQuery(ctx context.Context, request *api.Request) (respo
 				return nil, err
 			}
 
+			var underlyingError error
+			response, underlyingError = json.Marshal(result)
+			if underlyingError != nil {
+				return nil, &api.LambdaError{Underlying: underlyingError, Status: http.StatusInternalServerError}
+			} else {
+				return response, nil
+			}
+			break
+		}
+	case "Query.getHotelsByIds":
+		{
+			var ids []string
+			json.Unmarshal(request.Args["ids"], &ids)
+			result, err := e.queryResolver.Query_getHotelsByIds(ctx, ids, request.AuthHeader)
+			if err != nil {
+				return nil, err
+			}
+
 			var underlyingError error
 			response, underlyingError = json.Marshal(result)
 			if underlyingError != nil {
@@ -280,15 +387,238 @@ func (e Executer) resolveMutation(ctx context.Context, request *api.Request) (re
 
 func (e Executer) resolveWebhook(ctx context.Context, request *api.Request) (err *api.LambdaError) {
 	switch request.Event.TypeName {
-	case "Hotel":
-		err = e.webhookResolver.Webhook_Hotel(ctx, request.Event)
-		return err
+	case "Comment":
+		switch request.Event.Operation {
+		case "add":
+			{
+				var event *model.PostAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Post_add") {
+					if err = e.webhookResolver.Webhook_Post_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Post_add")
+				}
+			}
+			return nil
+		case "update":
+			{
+				var event *model.PostUpdateEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Post_update") {
+					if err = e.webhookResolver.Webhook_Post_update(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Post_update")
+				}
+			}
+			return nil
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for Comment"), Status: http.StatusBadRequest}
 	case "CyclicType":
-		err = e.webhookResolver.Webhook_CyclicType(ctx, request.Event)
-		return err
+		switch request.Event.Operation {
+		case "add":
+			{
+				var event *model.CyclicTypeAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_CyclicType_add") {
+					if err = e.webhookResolver.Webhook_CyclicType_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_CyclicType_add")
+				}
+			}
+			return nil
+		case "delete":
+			{
+				var event *model.CyclicTypeDeleteEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_CyclicType_delete") {
+					if err = e.webhookResolver.Webhook_CyclicType_delete(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_CyclicType_delete")
+				}
+			}
+			return nil
+		case "update":
+			{
+				var event *model.CyclicTypeUpdateEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_CyclicType_update") {
+					if err = e.webhookResolver.Webhook_CyclicType_update(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_CyclicType_update")
+				}
+			}
+			return nil
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for CyclicType"), Status: http.StatusBadRequest}
+	case "Hotel":
+		switch request.Event.Operation {
+		case "add":
+			{
+				var event *model.HotelAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Hotel_add") {
+					if err = e.webhookResolver.Webhook_Hotel_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Hotel_add")
+				}
+			}
+			return nil
+		case "delete":
+			{
+				var event *model.HotelDeleteEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Hotel_delete") {
+					if err = e.webhookResolver.Webhook_Hotel_delete(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Hotel_delete")
+				}
+			}
+			return nil
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for Hotel"), Status: http.StatusBadRequest}
+	case "Post":
+		switch request.Event.Operation {
+		case "add":
+			{
+				var event *model.PostAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Post_add") {
+					if err = e.webhookResolver.Webhook_Post_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Post_add")
+				}
+			}
+			return nil
+		case "update":
+			{
+				var event *model.PostUpdateEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Post_update") {
+					if err = e.webhookResolver.Webhook_Post_update(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Post_update")
+				}
+			}
+			return nil
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for Post"), Status: http.StatusBadRequest}
+	case "Question":
+		switch request.Event.Operation {
+		case "add":
+			{
+				var event *model.QuestionAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Question_add") {
+					if err = e.webhookResolver.Webhook_Question_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Question_add")
+				}
+			}
+			{
+				var event *model.PostAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Post_add") {
+					if err = e.webhookResolver.Webhook_Post_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Post_add")
+				}
+			}
+			return nil
+		case "update":
+			{
+				var event *model.PostUpdateEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_Post_update") {
+					if err = e.webhookResolver.Webhook_Post_update(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_Post_update")
+				}
+			}
+			return nil
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for Question"), Status: http.StatusBadRequest}
 	case "User":
-		err = e.webhookResolver.Webhook_User(ctx, request.Event)
-		return err
+		switch request.Event.Operation {
+		case "add":
+			{
+				var event *model.UserAddEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_User_add") {
+					if err = e.webhookResolver.Webhook_User_add(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_User_add")
+				}
+			}
+			return nil
+		case "delete":
+			{
+				var event *model.UserDeleteEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_User_delete") {
+					if err = e.webhookResolver.Webhook_User_delete(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_User_delete")
+				}
+			}
+			return nil
+		case "update":
+			{
+				var event *model.UserUpdateEvent
+				if underlyingError := api.DecodeEvent(request.Event, &event); underlyingError != nil {
+					return &api.LambdaError{Underlying: underlyingError, Status: http.StatusBadRequest}
+				}
+				if !api.HookCompleted(ctx, "Webhook_User_update") {
+					if err = e.webhookResolver.Webhook_User_update(ctx, event); err != nil {
+						return err
+					}
+					api.CompleteHook(ctx, "Webhook_User_update")
+				}
+			}
+			return nil
+		}
+		return &api.LambdaError{Underlying: errors.New("operation " + request.Event.Operation + " is not enabled for User"), Status: http.StatusBadRequest}
 	}
 
 	return &api.LambdaError{Underlying: errors.New("could not find webhook resolver"), Status: http.StatusNotFound}
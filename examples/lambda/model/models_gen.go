@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -10,60 +11,993 @@ import (
 	"github.com/twpayne/go-geom"
 )
 
+type Animal interface {
+	IsAnimal()
+	GetId() string
+	GetCategory() *Category
+}
 type Color interface {
 	IsColor()
+	GetId() string
+	GetColor() string
 }
 type Fruit interface {
 	IsFruit()
+	GetId() string
+	GetPrice() int64
+}
+
+// HomeMember is a union of Dog | Human | Parrot
+type HomeMember interface {
+	IsHomeMember()
 }
 type Post interface {
 	IsPost()
+	GetId() string
+	GetTitle() string
+	GetText() *string
+	GetDatePublished() *time.Time
+	GetTags() []Tag
+	GetAuthor() *Author
+	GetAdditionalInfo() *string
 }
 type Shape interface {
 	IsShape()
+	GetId() string
+	GetShape() string
+}
+type AddCyclicTypeInput struct {
+	Name        string          `json:"name" dql:"CyclicType.name"`
+	InverseType *InverseTypeRef `json:"inverseType" dql:"CyclicType.inverseType"`
+}
+type AddHotelInput struct {
+	Id       string      `json:"id" dql:"Hotel.id"`
+	Name     string      `json:"name" dql:"Hotel.name"`
+	Location *PointRef   `json:"location" dql:"Hotel.location"`
+	Area     *PolygonRef `json:"area" dql:"Hotel.area"`
+}
+type AddPostInput struct {
+	Title         string     `json:"title" dql:"Post.title"`
+	Text          *string    `json:"text" dql:"Post.text"`
+	DatePublished *time.Time `json:"datePublished" dql:"Post.datePublished"`
+	Tags          []Tag      `json:"tags" dql:"Post.tags"`
+	Author        *AuthorRef `json:"author" dql:"Post.author"`
+}
+type AddQuestionInput struct {
+	Title          string     `json:"title" dql:"Post.title"`
+	Text           *string    `json:"text" dql:"Post.text"`
+	DatePublished  *time.Time `json:"datePublished" dql:"Post.datePublished"`
+	Tags           []Tag      `json:"tags" dql:"Post.tags"`
+	Author         *AuthorRef `json:"author" dql:"Post.author"`
+	AdditionalInfo *string    `json:"additionalInfo" dql:"Post.additionalInfo"`
+}
+type AddUserInput struct {
+	Credentials  *CredentialsRef `json:"credentials" dql:"User.credentials"`
+	Name         string          `json:"name" dql:"User.name"`
+	LastSignIn   *time.Time      `json:"lastSignIn" dql:"User.lastSignIn"`
+	RecentScores []*float64      `json:"recentScores" dql:"User.recentScores"`
+	Likes        *int64          `json:"likes" dql:"User.likes"`
 }
 type Apple struct {
 	Id    string `json:"id" dql:"uid"`
-	Price int64  `json:"price" dql:"Apple.price"`
+	Price int64  `json:"price" dql:"Fruit.price"`
 	Color string `json:"color" dql:"Apple.color"`
 }
+
+func (m Apple) IsFruit() {}
+
+func (m Apple) GetId() string { return m.Id }
+
+func (m Apple) GetPrice() int64 { return m.Price }
+
 type Author struct {
 	Id            string    `json:"id" dql:"uid"`
 	Name          string    `json:"name" dql:"Author.name"`
-	Posts         []*Post   `json:"posts" dql:"Author.posts"`
-	RecentlyLiked []*Post   `json:"recentlyLiked" dql:"Author.recentlyLiked"`
+	Posts         []Post    `json:"posts" dql:"Author.posts"`
+	RecentlyLiked []Post    `json:"recentlyLiked" dql:"Author.recentlyLiked"`
 	Friends       []*Author `json:"friends" dql:"Author.friends"`
 }
+
+func (m *Author) UnmarshalJSON(data []byte) error {
+	type alias Author
+	var raw struct {
+		*alias
+		Posts         []json.RawMessage `json:"posts"`
+		RecentlyLiked []json.RawMessage `json:"recentlyLiked"`
+	}
+	raw.alias = (*alias)(m)
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	if raw.Posts != nil {
+		m.Posts = make([]Post, len(raw.Posts))
+		for i0 := range raw.Posts {
+			if m.Posts[i0], err = UnmarshalPost(raw.Posts[i0]); err != nil {
+				return err
+			}
+		}
+	}
+	if raw.RecentlyLiked != nil {
+		m.RecentlyLiked = make([]Post, len(raw.RecentlyLiked))
+		for i0 := range raw.RecentlyLiked {
+			if m.RecentlyLiked[i0], err = UnmarshalPost(raw.RecentlyLiked[i0]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m Author) MarshalJSON() ([]byte, error) {
+	type alias Author
+	raw := struct {
+		alias
+		Posts         []json.RawMessage `json:"posts"`
+		RecentlyLiked []json.RawMessage `json:"recentlyLiked"`
+	}{alias: alias(m)}
+	var err error
+	if m.Posts != nil {
+		raw.Posts = make([]json.RawMessage, len(m.Posts))
+		for i0 := range m.Posts {
+			if raw.Posts[i0], err = MarshalPost(m.Posts[i0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if m.RecentlyLiked != nil {
+		raw.RecentlyLiked = make([]json.RawMessage, len(m.RecentlyLiked))
+		for i0 := range m.RecentlyLiked {
+			if raw.RecentlyLiked[i0], err = MarshalPost(m.RecentlyLiked[i0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return json.Marshal(raw)
+}
+
+type AuthorRef struct {
+	Id            *string      `json:"id,omitempty" dql:"uid"`
+	Name          *string      `json:"name,omitempty" dql:"Author.name"`
+	Posts         []*PostRef   `json:"posts,omitempty" dql:"Author.posts"`
+	RecentlyLiked []*PostRef   `json:"recentlyLiked,omitempty" dql:"Author.recentlyLiked"`
+	Friends       []*AuthorRef `json:"friends,omitempty" dql:"Author.friends"`
+	Pwd           *string      `json:"pwd,omitempty" dql:"Author.pwd"`
+}
+type Banana struct {
+	Id    string `json:"id" dql:"uid"`
+	Price int64  `json:"price" dql:"Fruit.price"`
+}
+
+func (m Banana) IsFruit() {}
+
+func (m Banana) GetId() string { return m.Id }
+
+func (m Banana) GetPrice() int64 { return m.Price }
+
+type Cheetah struct {
+	Id       string    `json:"id" dql:"uid"`
+	Speed    *float64  `json:"speed" dql:"Cheetah.speed"`
+	Category *Category `json:"category" dql:"Animal.category"`
+}
+
+func (m Cheetah) IsAnimal() {}
+
+func (m Cheetah) GetId() string { return m.Id }
+
+func (m Cheetah) GetCategory() *Category { return m.Category }
+
+type Comment struct {
+	Id             string     `json:"id" dql:"uid"`
+	Title          string     `json:"title" dql:"Post.title"`
+	Text           *string    `json:"text" dql:"Post.text"`
+	DatePublished  *time.Time `json:"datePublished" dql:"Post.datePublished"`
+	CommentsOn     Post       `json:"commentsOn" dql:"Comment.commentsOn"`
+	Tags           []Tag      `json:"tags" dql:"Post.tags"`
+	Author         *Author    `json:"author" dql:"Post.author"`
+	AdditionalInfo *string    `json:"additionalInfo" dql:"Post.additionalInfo"`
+}
+
+func (m Comment) IsPost() {}
+
+func (m Comment) GetId() string { return m.Id }
+
+func (m Comment) GetTitle() string { return m.Title }
+
+func (m Comment) GetText() *string { return m.Text }
+
+func (m Comment) GetDatePublished() *time.Time { return m.DatePublished }
+
+func (m Comment) GetTags() []Tag { return m.Tags }
+
+func (m Comment) GetAuthor() *Author { return m.Author }
+
+func (m Comment) GetAdditionalInfo() *string { return m.AdditionalInfo }
+
+func (m *Comment) UnmarshalJSON(data []byte) error {
+	type alias Comment
+	var raw struct {
+		*alias
+		CommentsOn json.RawMessage `json:"commentsOn"`
+	}
+	raw.alias = (*alias)(m)
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	if m.CommentsOn, err = UnmarshalPost(raw.CommentsOn); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m Comment) MarshalJSON() ([]byte, error) {
+	type alias Comment
+	raw := struct {
+		alias
+		CommentsOn json.RawMessage `json:"commentsOn"`
+	}{alias: alias(m)}
+	var err error
+	if raw.CommentsOn, err = MarshalPost(m.CommentsOn); err != nil {
+		return nil, err
+	}
+	return json.Marshal(raw)
+}
+
+type CredentialsRef struct {
+	Id         *string `json:"id,omitempty" dql:"uid"`
+	FirebaseId *string `json:"firebaseId,omitempty" dql:"Credentials.firebaseId"`
+}
 type CyclicType struct {
 	Id          string       `json:"id" dql:"uid"`
 	Name        string       `json:"name" dql:"CyclicType.name"`
 	InverseType *InverseType `json:"inverseType" dql:"CyclicType.inverseType"`
 }
+type CyclicTypePatch struct {
+	Name        *string         `json:"name,omitempty" dql:"CyclicType.name"`
+	InverseType *InverseTypeRef `json:"inverseType,omitempty" dql:"CyclicType.inverseType"`
+}
+type CyclicTypeRef struct {
+	Id          *string         `json:"id,omitempty" dql:"uid"`
+	Name        *string         `json:"name,omitempty" dql:"CyclicType.name"`
+	InverseType *InverseTypeRef `json:"inverseType,omitempty" dql:"CyclicType.inverseType"`
+}
+type Dog struct {
+	Id       string    `json:"id" dql:"uid"`
+	Breed    *string   `json:"breed" dql:"Dog.breed"`
+	Category *Category `json:"category" dql:"Animal.category"`
+}
+
+func (m Dog) IsAnimal() {}
+
+func (m Dog) GetId() string { return m.Id }
+
+func (m Dog) GetCategory() *Category { return m.Category }
+
+func (m Dog) IsHomeMember() {}
+
 type Figure struct {
 	Id    string `json:"id" dql:"uid"`
-	Shape string `json:"shape" dql:"Figure.shape"`
-	Color string `json:"color" dql:"Figure.color"`
+	Shape string `json:"shape" dql:"Shape.shape"`
+	Color string `json:"color" dql:"Color.color"`
 	Size  int64  `json:"size" dql:"Figure.size"`
 }
+
+func (m Figure) IsShape() {}
+
+func (m Figure) GetId() string { return m.Id }
+
+func (m Figure) GetShape() string { return m.Shape }
+
+func (m Figure) IsColor() {}
+
+func (m Figure) GetColor() string { return m.Color }
+
 type Hotel struct {
-	Id       string  `json:"id" dql:"uid"`
+	Id       string  `json:"id" dql:"Hotel.id"`
 	Name     string  `json:"name" dql:"Hotel.name"`
 	Location *geom.T `json:"location" dql:"Hotel.location"`
 	Area     *geom.T `json:"area" dql:"Hotel.area"`
 }
+type HotelPatch struct {
+	Id       *string     `json:"id,omitempty" dql:"Hotel.id"`
+	Name     *string     `json:"name,omitempty" dql:"Hotel.name"`
+	Location *PointRef   `json:"location,omitempty" dql:"Hotel.location"`
+	Area     *PolygonRef `json:"area,omitempty" dql:"Hotel.area"`
+}
+type Human struct {
+	Name string   `json:"name" dql:"Human.name"`
+	Pets []Animal `json:"pets" dql:"Human.pets"`
+}
+
+func (m Human) IsHomeMember() {}
+
+func (m *Human) UnmarshalJSON(data []byte) error {
+	type alias Human
+	var raw struct {
+		*alias
+		Pets []json.RawMessage `json:"pets"`
+	}
+	raw.alias = (*alias)(m)
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	if raw.Pets != nil {
+		m.Pets = make([]Animal, len(raw.Pets))
+		for i0 := range raw.Pets {
+			if m.Pets[i0], err = UnmarshalAnimal(raw.Pets[i0]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m Human) MarshalJSON() ([]byte, error) {
+	type alias Human
+	raw := struct {
+		alias
+		Pets []json.RawMessage `json:"pets"`
+	}{alias: alias(m)}
+	var err error
+	if m.Pets != nil {
+		raw.Pets = make([]json.RawMessage, len(m.Pets))
+		for i0 := range m.Pets {
+			if raw.Pets[i0], err = MarshalAnimal(m.Pets[i0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return json.Marshal(raw)
+}
+
+type InverseTypeRef struct {
+	Id         *string        `json:"id,omitempty" dql:"uid"`
+	Name       *string        `json:"name,omitempty" dql:"InverseType.name"`
+	CyclicType *CyclicTypeRef `json:"cyclicType,omitempty" dql:"InverseType.cyclicType"`
+}
+type Parrot struct {
+	Id           string    `json:"id" dql:"uid"`
+	RepeatsWords []*string `json:"repeatsWords" dql:"Parrot.repeatsWords"`
+	Category     *Category `json:"category" dql:"Animal.category"`
+}
+
+func (m Parrot) IsAnimal() {}
+
+func (m Parrot) GetId() string { return m.Id }
+
+func (m Parrot) GetCategory() *Category { return m.Category }
+
+func (m Parrot) IsHomeMember() {}
+
 type PointList struct {
 	Points []*geom.T `json:"points" dql:"PointList.points"`
 }
+type PointListRef struct {
+	Points []*PointRef `json:"points" dql:"PointListRef.points"`
+}
+type PointRef struct {
+	Longitude float64 `json:"longitude" dql:"PointRef.longitude"`
+	Latitude  float64 `json:"latitude" dql:"PointRef.latitude"`
+}
+type PolygonRef struct {
+	Coordinates []*PointListRef `json:"coordinates" dql:"PolygonRef.coordinates"`
+}
+type PostPatch struct {
+	Title         *string    `json:"title,omitempty" dql:"Post.title"`
+	Text          *string    `json:"text,omitempty" dql:"Post.text"`
+	DatePublished *time.Time `json:"datePublished,omitempty" dql:"Post.datePublished"`
+	Tags          []Tag      `json:"tags,omitempty" dql:"Post.tags"`
+	Author        *AuthorRef `json:"author,omitempty" dql:"Post.author"`
+}
+type PostRef struct {
+	Id *string `json:"id,omitempty" dql:"uid"`
+}
+type Question struct {
+	Id             string     `json:"id" dql:"uid"`
+	Title          string     `json:"title" dql:"Post.title"`
+	Text           *string    `json:"text" dql:"Post.text"`
+	DatePublished  *time.Time `json:"datePublished" dql:"Post.datePublished"`
+	Tags           []Tag      `json:"tags" dql:"Post.tags"`
+	Author         *Author    `json:"author" dql:"Post.author"`
+	AdditionalInfo *string    `json:"additionalInfo" dql:"Post.additionalInfo"`
+}
+
+func (m Question) IsPost() {}
+
+func (m Question) GetId() string { return m.Id }
+
+func (m Question) GetTitle() string { return m.Title }
+
+func (m Question) GetText() *string { return m.Text }
+
+func (m Question) GetDatePublished() *time.Time { return m.DatePublished }
+
+func (m Question) GetTags() []Tag { return m.Tags }
+
+func (m Question) GetAuthor() *Author { return m.Author }
+
+func (m Question) GetAdditionalInfo() *string { return m.AdditionalInfo }
+
+type QuestionPatch struct {
+	Title          *string    `json:"title,omitempty" dql:"Post.title"`
+	Text           *string    `json:"text,omitempty" dql:"Post.text"`
+	DatePublished  *time.Time `json:"datePublished,omitempty" dql:"Post.datePublished"`
+	Tags           []Tag      `json:"tags,omitempty" dql:"Post.tags"`
+	Author         *AuthorRef `json:"author,omitempty" dql:"Post.author"`
+	AdditionalInfo *string    `json:"additionalInfo,omitempty" dql:"Post.additionalInfo"`
+}
 type User struct {
-	UserID       string              `json:"userID" dql:"User.userID"`
+	UserID       string              `json:"userID" dql:"uid"`
 	Credentials  *models.Credentials `json:"credentials" dql:"User.credentials"`
 	Name         string              `json:"name" dql:"User.name"`
 	LastSignIn   *time.Time          `json:"lastSignIn" dql:"User.lastSignIn"`
-	RecentScores []float64           `json:"recentScores" dql:"User.recentScores"`
-	Likes        int64               `json:"likes" dql:"User.likes"`
-	Reputation   int64               `json:"reputation" dql:"User.reputation"`
-	Rank         int64               `json:"rank" dql:"User.rank"`
-	Active       bool                `json:"active" dql:"User.active"`
+	RecentScores []*float64          `json:"recentScores" dql:"User.recentScores"`
+	Likes        *int64              `json:"likes" dql:"User.likes"`
+	Reputation   *int64              `json:"reputation" dql:"User.reputation"`
+	Rank         *int64              `json:"rank" dql:"User.rank"`
+	Active       *bool               `json:"active" dql:"User.active"`
+}
+type UserPatch struct {
+	Credentials  *CredentialsRef `json:"credentials,omitempty" dql:"User.credentials"`
+	Name         *string         `json:"name,omitempty" dql:"User.name"`
+	LastSignIn   *time.Time      `json:"lastSignIn,omitempty" dql:"User.lastSignIn"`
+	RecentScores []*float64      `json:"recentScores,omitempty" dql:"User.recentScores"`
+	Likes        *int64          `json:"likes,omitempty" dql:"User.likes"`
+}
+
+// UnmarshalAnimal decodes a Animal into the implementing type named by typeNames, __typename or dgraph.type, in this order.
+func UnmarshalAnimal(data []byte, typeNames ...string) (Animal, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   `json:"__typename"`
+		DgraphType []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		case "Cheetah":
+			var value *Cheetah
+			err := json.Unmarshal(data, &value)
+			return value, err
+		case "Dog":
+			var value *Dog
+			err := json.Unmarshal(data, &value)
+			return value, err
+		case "Parrot":
+			var value *Parrot
+			err := json.Unmarshal(data, &value)
+			return value, err
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of Animal from %v", typeNames)
+}
+
+// MarshalAnimal encodes a Animal with the __typename of its implementing type, which Dgraph needs to resolve the type.
+func MarshalAnimal(value Animal) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case *Cheetah, Cheetah:
+		return withTypeName(data, "Cheetah"), nil
+	case *Dog, Dog:
+		return withTypeName(data, "Dog"), nil
+	case *Parrot, Parrot:
+		return withTypeName(data, "Parrot"), nil
+	}
+	return data, nil
+}
+
+// UnmarshalAnimalList decodes a list of Animal.
+func UnmarshalAnimalList(data []byte, typeNames ...string) ([]Animal, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make([]Animal, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = UnmarshalAnimal(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// UnmarshalColor decodes a Color into the implementing type named by typeNames, __typename or dgraph.type, in this order.
+func UnmarshalColor(data []byte, typeNames ...string) (Color, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   `json:"__typename"`
+		DgraphType []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		case "Figure":
+			var value *Figure
+			err := json.Unmarshal(data, &value)
+			return value, err
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of Color from %v", typeNames)
+}
+
+// MarshalColor encodes a Color with the __typename of its implementing type, which Dgraph needs to resolve the type.
+func MarshalColor(value Color) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case *Figure, Figure:
+		return withTypeName(data, "Figure"), nil
+	}
+	return data, nil
+}
+
+// UnmarshalColorList decodes a list of Color.
+func UnmarshalColorList(data []byte, typeNames ...string) ([]Color, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make([]Color, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = UnmarshalColor(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// UnmarshalFruit decodes a Fruit into the implementing type named by typeNames, __typename or dgraph.type, in this order.
+func UnmarshalFruit(data []byte, typeNames ...string) (Fruit, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   `json:"__typename"`
+		DgraphType []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		case "Apple":
+			var value *Apple
+			err := json.Unmarshal(data, &value)
+			return value, err
+		case "Banana":
+			var value *Banana
+			err := json.Unmarshal(data, &value)
+			return value, err
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of Fruit from %v", typeNames)
+}
+
+// MarshalFruit encodes a Fruit with the __typename of its implementing type, which Dgraph needs to resolve the type.
+func MarshalFruit(value Fruit) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case *Apple, Apple:
+		return withTypeName(data, "Apple"), nil
+	case *Banana, Banana:
+		return withTypeName(data, "Banana"), nil
+	}
+	return data, nil
+}
+
+// UnmarshalFruitList decodes a list of Fruit.
+func UnmarshalFruitList(data []byte, typeNames ...string) ([]Fruit, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make([]Fruit, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = UnmarshalFruit(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// UnmarshalHomeMember decodes a HomeMember into the member named by typeNames, __typename or dgraph.type, in this order.
+func UnmarshalHomeMember(data []byte, typeNames ...string) (HomeMember, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   `json:"__typename"`
+		DgraphType []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		case "Dog":
+			var value *Dog
+			err := json.Unmarshal(data, &value)
+			return value, err
+		case "Human":
+			var value *Human
+			err := json.Unmarshal(data, &value)
+			return value, err
+		case "Parrot":
+			var value *Parrot
+			err := json.Unmarshal(data, &value)
+			return value, err
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of HomeMember from %v", typeNames)
+}
+
+// MarshalHomeMember encodes a HomeMember with the __typename of its member, which Dgraph needs to resolve the type.
+func MarshalHomeMember(value HomeMember) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case *Dog, Dog:
+		return withTypeName(data, "Dog"), nil
+	case *Human, Human:
+		return withTypeName(data, "Human"), nil
+	case *Parrot, Parrot:
+		return withTypeName(data, "Parrot"), nil
+	}
+	return data, nil
+}
+
+// UnmarshalHomeMemberList decodes a list of HomeMember.
+func UnmarshalHomeMemberList(data []byte, typeNames ...string) ([]HomeMember, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make([]HomeMember, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = UnmarshalHomeMember(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// UnmarshalPost decodes a Post into the implementing type named by typeNames, __typename or dgraph.type, in this order.
+func UnmarshalPost(data []byte, typeNames ...string) (Post, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   `json:"__typename"`
+		DgraphType []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		case "Comment":
+			var value *Comment
+			err := json.Unmarshal(data, &value)
+			return value, err
+		case "Question":
+			var value *Question
+			err := json.Unmarshal(data, &value)
+			return value, err
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of Post from %v", typeNames)
+}
+
+// MarshalPost encodes a Post with the __typename of its implementing type, which Dgraph needs to resolve the type.
+func MarshalPost(value Post) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case *Comment, Comment:
+		return withTypeName(data, "Comment"), nil
+	case *Question, Question:
+		return withTypeName(data, "Question"), nil
+	}
+	return data, nil
+}
+
+// UnmarshalPostList decodes a list of Post.
+func UnmarshalPostList(data []byte, typeNames ...string) ([]Post, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make([]Post, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = UnmarshalPost(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// UnmarshalShape decodes a Shape into the implementing type named by typeNames, __typename or dgraph.type, in this order.
+func UnmarshalShape(data []byte, typeNames ...string) (Shape, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var typed struct {
+		TypeName   string   `json:"__typename"`
+		DgraphType []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	typeNames = append(append(append([]string{}, typeNames...), typed.TypeName), typed.DgraphType...)
+	for _, typeName := range typeNames {
+		switch typeName {
+		case "Figure":
+			var value *Figure
+			err := json.Unmarshal(data, &value)
+			return value, err
+		}
+	}
+	return nil, fmt.Errorf("could not determine the type of Shape from %v", typeNames)
+}
+
+// MarshalShape encodes a Shape with the __typename of its implementing type, which Dgraph needs to resolve the type.
+func MarshalShape(value Shape) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case *Figure, Figure:
+		return withTypeName(data, "Figure"), nil
+	}
+	return data, nil
+}
+
+// UnmarshalShapeList decodes a list of Shape.
+func UnmarshalShapeList(data []byte, typeNames ...string) ([]Shape, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, err
+	}
+	values := make([]Shape, len(raw))
+	for i := range raw {
+		var err error
+		if values[i], err = UnmarshalShape(raw[i], typeNames...); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// withTypeName adds the __typename to an encoded object.
+func withTypeName(data []byte, typeName string) []byte {
+	if len(data) < 2 || data[0] != '{' {
+		return data
+	}
+	object := []byte("{\"__typename\":\"" + typeName + "\"")
+	if len(data) > 2 {
+		object = append(object, ',')
+	}
+	return append(object, data[1:]...)
+}
+
+type CyclicTypeAddEvent struct {
+	TypeName  string                  `json:"__typename"`
+	CommitTs  uint64                  `json:"commitTs"`
+	Operation string                  `json:"operation"`
+	Add       *CyclicTypeAddEventInfo `json:"add"`
+}
+
+type CyclicTypeAddEventInfo struct {
+	RootUIDs []string              `json:"rootUIDs"`
+	Input    []*AddCyclicTypeInput `json:"input"`
+}
+
+type CyclicTypeUpdateEvent struct {
+	TypeName  string                     `json:"__typename"`
+	CommitTs  uint64                     `json:"commitTs"`
+	Operation string                     `json:"operation"`
+	Update    *CyclicTypeUpdateEventInfo `json:"update"`
+}
+
+type CyclicTypeUpdateEventInfo struct {
+	RootUIDs    []string         `json:"rootUIDs"`
+	SetPatch    *CyclicTypePatch `json:"setPatch"`
+	RemovePatch *CyclicTypePatch `json:"removePatch"`
+}
+
+type CyclicTypeDeleteEvent struct {
+	TypeName  string                     `json:"__typename"`
+	CommitTs  uint64                     `json:"commitTs"`
+	Operation string                     `json:"operation"`
+	Delete    *CyclicTypeDeleteEventInfo `json:"delete"`
+}
+
+type CyclicTypeDeleteEventInfo struct {
+	RootUIDs []string `json:"rootUIDs"`
+}
+
+type HotelAddEvent struct {
+	TypeName  string             `json:"__typename"`
+	CommitTs  uint64             `json:"commitTs"`
+	Operation string             `json:"operation"`
+	Add       *HotelAddEventInfo `json:"add"`
+}
+
+type HotelAddEventInfo struct {
+	RootUIDs []string         `json:"rootUIDs"`
+	Input    []*AddHotelInput `json:"input"`
+}
+
+type HotelDeleteEvent struct {
+	TypeName  string                `json:"__typename"`
+	CommitTs  uint64                `json:"commitTs"`
+	Operation string                `json:"operation"`
+	Delete    *HotelDeleteEventInfo `json:"delete"`
+}
+
+type HotelDeleteEventInfo struct {
+	RootUIDs []string `json:"rootUIDs"`
+}
+
+type PostAddEvent struct {
+	TypeName  string            `json:"__typename"`
+	CommitTs  uint64            `json:"commitTs"`
+	Operation string            `json:"operation"`
+	Add       *PostAddEventInfo `json:"add"`
+}
+
+type PostAddEventInfo struct {
+	RootUIDs []string        `json:"rootUIDs"`
+	Input    []*AddPostInput `json:"input"`
+}
+
+type PostUpdateEvent struct {
+	TypeName  string               `json:"__typename"`
+	CommitTs  uint64               `json:"commitTs"`
+	Operation string               `json:"operation"`
+	Update    *PostUpdateEventInfo `json:"update"`
+}
+
+type PostUpdateEventInfo struct {
+	RootUIDs    []string   `json:"rootUIDs"`
+	SetPatch    *PostPatch `json:"setPatch"`
+	RemovePatch *PostPatch `json:"removePatch"`
+}
+
+type QuestionAddEvent struct {
+	TypeName  string                `json:"__typename"`
+	CommitTs  uint64                `json:"commitTs"`
+	Operation string                `json:"operation"`
+	Add       *QuestionAddEventInfo `json:"add"`
+}
+
+type QuestionAddEventInfo struct {
+	RootUIDs []string            `json:"rootUIDs"`
+	Input    []*AddQuestionInput `json:"input"`
+}
+
+type UserAddEvent struct {
+	TypeName  string            `json:"__typename"`
+	CommitTs  uint64            `json:"commitTs"`
+	Operation string            `json:"operation"`
+	Add       *UserAddEventInfo `json:"add"`
+}
+
+type UserAddEventInfo struct {
+	RootUIDs []string        `json:"rootUIDs"`
+	Input    []*AddUserInput `json:"input"`
+}
+
+type UserUpdateEvent struct {
+	TypeName  string               `json:"__typename"`
+	CommitTs  uint64               `json:"commitTs"`
+	Operation string               `json:"operation"`
+	Update    *UserUpdateEventInfo `json:"update"`
+}
+
+type UserUpdateEventInfo struct {
+	RootUIDs    []string   `json:"rootUIDs"`
+	SetPatch    *UserPatch `json:"setPatch"`
+	RemovePatch *UserPatch `json:"removePatch"`
+}
+
+type UserDeleteEvent struct {
+	TypeName  string               `json:"__typename"`
+	CommitTs  uint64               `json:"commitTs"`
+	Operation string               `json:"operation"`
+	Delete    *UserDeleteEventInfo `json:"delete"`
+}
+
+type UserDeleteEventInfo struct {
+	RootUIDs []string `json:"rootUIDs"`
+}
+
+type Category string
+
+const (
+	CategoryFish         Category = "Fish"
+	CategoryAmphibian    Category = "Amphibian"
+	CategoryReptile      Category = "Reptile"
+	CategoryBird         Category = "Bird"
+	CategoryMammal       Category = "Mammal"
+	CategoryInVertebrate Category = "InVertebrate"
+)
+
+var AllCategory = []Category{
+	CategoryFish,
+	CategoryAmphibian,
+	CategoryReptile,
+	CategoryBird,
+	CategoryMammal,
+	CategoryInVertebrate,
+}
+
+func (e Category) IsValid() bool {
+	switch e {
+	case CategoryFish, CategoryAmphibian, CategoryReptile, CategoryBird, CategoryMammal, CategoryInVertebrate:
+		return true
+	}
+	return false
+}
+
+func (e Category) String() string {
+	return string(e)
+}
+
+func (e *Category) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Category(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Category", str)
+	}
+	return nil
+}
+
+func (e Category) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 type Tag string
@@ -9,7 +9,9 @@ import (
 
 type QueryResolverInterface interface {
 	Query_getApples(ctx context.Context, authHeader api.AuthHeader) ([]*model.Apple, *api.LambdaError)
+	Query_getHomeMembers(ctx context.Context, homeId string, authHeader api.AuthHeader) ([]model.HomeMember, *api.LambdaError)
 	Query_getHotelByName(ctx context.Context, name string, authHeader api.AuthHeader) (*model.Hotel, *api.LambdaError)
+	Query_getHotelsByIds(ctx context.Context, ids []string, authHeader api.AuthHeader) ([]*model.Hotel, *api.LambdaError)
 	Query_getTopAuthors(ctx context.Context, id string, authHeader api.AuthHeader) ([]*model.Author, *api.LambdaError)
 }
 
@@ -21,10 +23,18 @@ func (q *QueryResolver) Query_getApples(ctx context.Context, authHeader api.Auth
 	return nil, nil
 }
 
+func (q *QueryResolver) Query_getHomeMembers(ctx context.Context, homeId string, authHeader api.AuthHeader) ([]model.HomeMember, *api.LambdaError) {
+	return nil, nil
+}
+
 func (q *QueryResolver) Query_getHotelByName(ctx context.Context, name string, authHeader api.AuthHeader) (*model.Hotel, *api.LambdaError) {
 	return nil, nil
 }
 
+func (q *QueryResolver) Query_getHotelsByIds(ctx context.Context, ids []string, authHeader api.AuthHeader) ([]*model.Hotel, *api.LambdaError) {
+	return nil, nil
+}
+
 func (q *QueryResolver) Query_getTopAuthors(ctx context.Context, id string, authHeader api.AuthHeader) ([]*model.Author, *api.LambdaError) {
 	return nil, nil
 }
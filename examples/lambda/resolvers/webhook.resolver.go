@@ -4,26 +4,67 @@ import (
 	"context"
 
 	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/model"
 )
 
 type WebhookResolverInterface interface {
-	Webhook_CyclicType(ctx context.Context, event *api.Event) *api.LambdaError
-	Webhook_Hotel(ctx context.Context, event *api.Event) *api.LambdaError
-	Webhook_User(ctx context.Context, event *api.Event) *api.LambdaError
+	Webhook_CyclicType_add(ctx context.Context, event *model.CyclicTypeAddEvent) *api.LambdaError
+	Webhook_CyclicType_update(ctx context.Context, event *model.CyclicTypeUpdateEvent) *api.LambdaError
+	Webhook_CyclicType_delete(ctx context.Context, event *model.CyclicTypeDeleteEvent) *api.LambdaError
+	Webhook_Hotel_add(ctx context.Context, event *model.HotelAddEvent) *api.LambdaError
+	Webhook_Hotel_delete(ctx context.Context, event *model.HotelDeleteEvent) *api.LambdaError
+	Webhook_Post_add(ctx context.Context, event *model.PostAddEvent) *api.LambdaError
+	Webhook_Post_update(ctx context.Context, event *model.PostUpdateEvent) *api.LambdaError
+	Webhook_Question_add(ctx context.Context, event *model.QuestionAddEvent) *api.LambdaError
+	Webhook_User_add(ctx context.Context, event *model.UserAddEvent) *api.LambdaError
+	Webhook_User_update(ctx context.Context, event *model.UserUpdateEvent) *api.LambdaError
+	Webhook_User_delete(ctx context.Context, event *model.UserDeleteEvent) *api.LambdaError
 }
 
 type WebhookResolver struct {
 	*Resolver
 }
 
-func (w *WebhookResolver) Webhook_CyclicType(ctx context.Context, event *api.Event) *api.LambdaError {
+func (w *WebhookResolver) Webhook_CyclicType_add(ctx context.Context, event *model.CyclicTypeAddEvent) *api.LambdaError {
 	return nil
 }
 
-func (w *WebhookResolver) Webhook_Hotel(ctx context.Context, event *api.Event) *api.LambdaError {
+func (w *WebhookResolver) Webhook_CyclicType_update(ctx context.Context, event *model.CyclicTypeUpdateEvent) *api.LambdaError {
 	return nil
 }
 
-func (w *WebhookResolver) Webhook_User(ctx context.Context, event *api.Event) *api.LambdaError {
+func (w *WebhookResolver) Webhook_CyclicType_delete(ctx context.Context, event *model.CyclicTypeDeleteEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_Hotel_add(ctx context.Context, event *model.HotelAddEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_Hotel_delete(ctx context.Context, event *model.HotelDeleteEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_Post_add(ctx context.Context, event *model.PostAddEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_Post_update(ctx context.Context, event *model.PostUpdateEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_Question_add(ctx context.Context, event *model.QuestionAddEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_User_add(ctx context.Context, event *model.UserAddEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_User_update(ctx context.Context, event *model.UserUpdateEvent) *api.LambdaError {
+	return nil
+}
+
+func (w *WebhookResolver) Webhook_User_delete(ctx context.Context, event *model.UserDeleteEvent) *api.LambdaError {
 	return nil
 }
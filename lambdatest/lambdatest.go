@@ -0,0 +1,71 @@
+// Package lambdatest provides helpers to test generated executers and resolvers.
+package lambdatest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+)
+
+type Result struct {
+	Body []byte
+	Err  *api.LambdaError
+}
+
+// Decode unmarshals the body of a successful result into v.
+func (r *Result) Decode(v interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return json.Unmarshal(r.Body, v)
+}
+
+// Resolve calls the executer directly.
+func Resolve(executer api.ExecuterInterface, request *api.Request) *Result {
+	body, err := executer.Resolve(context.Background(), request)
+	return &Result{Body: body, Err: err}
+}
+
+// RequireDecode fails the test if the result is an error or cannot be decoded into v.
+func RequireDecode(t testing.TB, result *Result, v interface{}) {
+	t.Helper()
+	if result.Err != nil {
+		t.Fatalf("Expected result, got error %d: %s", result.Err.Status, result.Err.Error())
+	}
+	if err := json.Unmarshal(result.Body, v); err != nil {
+		t.Fatalf("Could not decode result %s: %s", string(result.Body), err.Error())
+	}
+}
+
+// RequireError fails the test if the result is not an error with the given status.
+func RequireError(t testing.TB, result *Result, status api.HttpResponseStatus) *api.LambdaError {
+	t.Helper()
+	if result.Err == nil {
+		t.Fatalf("Expected error %d, got result %s", status, string(result.Body))
+	}
+	if result.Err.Status != status {
+		t.Fatalf("Expected error %d, got %d: %s", status, result.Err.Status, result.Err.Error())
+	}
+	return result.Err
+}
+
+// Server runs an api.Lambda in an in-process http server.
+type Server struct {
+	*httptest.Server
+	Lambda *api.Lambda
+}
+
+func NewServer(executer api.ExecuterInterface) *Server {
+	lambda := api.New(executer)
+	return &Server{Server: httptest.NewServer(http.HandlerFunc(lambda.Route)), Lambda: lambda}
+}
+
+// Do sends a request to the lambda server like Dgraph would.
+func (s *Server) Do(request *api.Request) *Result {
+	executer := &api.RemoteExecuter{URL: s.URL + "/graphql-worker", Client: s.Client()}
+	return Resolve(executer, request)
+}
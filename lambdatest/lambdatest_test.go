@@ -0,0 +1,40 @@
+package lambdatest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/generated"
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/model"
+	"github.com/schartey/dgraph-lambda-go/examples/lambda/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resolve(t *testing.T) {
+	executer := generated.NewExecuter(&resolvers.Resolver{})
+
+	var apples []*model.Apple
+	RequireDecode(t, Resolve(executer, Query("getApples").Request()), &apples)
+	assert.Nil(t, apples)
+
+	name := "x"
+	result := Resolve(executer, Webhook(UpdateEvent("Hotel", []string{"0x1"}, &model.HotelPatch{Name: &name}, nil)).Request())
+	err := RequireError(t, result, http.StatusBadRequest)
+	assert.Equal(t, "operation update is not enabled for Hotel", err.Error())
+}
+
+func Test_Server(t *testing.T) {
+	server := NewServer(generated.NewExecuter(&resolvers.Resolver{}))
+	defer server.Close()
+
+	var hotel *model.Hotel
+	RequireDecode(t, server.Do(Query("getHotelByName").Arg("name", "x").AuthHeader("Authorization", "token").Request()), &hotel)
+	assert.Nil(t, hotel)
+
+	result := server.Do(Webhook(AddEvent("Hotel", []string{"0x1"}, &model.AddHotelInput{Name: "x"})).Request())
+	assert.Nil(t, result.Err)
+
+	// Queries without arguments are rejected by the server
+	RequireError(t, server.Do(&api.Request{Resolver: "Query.getApples"}), http.StatusBadRequest)
+}
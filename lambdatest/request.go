@@ -0,0 +1,116 @@
+package lambdatest
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/schartey/dgraph-lambda-go/api"
+)
+
+// RequestBuilder builds requests in the shape Dgraph sends them to the lambda server.
+type RequestBuilder struct {
+	request *api.Request
+	err     error
+}
+
+func Query(name string) *RequestBuilder {
+	return &RequestBuilder{request: &api.Request{Resolver: "Query." + name, Args: make(map[string]json.RawMessage)}}
+}
+
+func Mutation(name string) *RequestBuilder {
+	return &RequestBuilder{request: &api.Request{Resolver: "Mutation." + name, Args: make(map[string]json.RawMessage)}}
+}
+
+// Field builds a request for the field resolver of typeName.field with the given parents.
+func Field(typeName string, field string, parents ...interface{}) *RequestBuilder {
+	b := &RequestBuilder{request: &api.Request{Resolver: typeName + "." + field}}
+	if parents == nil {
+		parents = []interface{}{}
+	}
+	b.request.Parents, b.err = json.Marshal(parents)
+	return b
+}
+
+func Webhook(event *api.Event) *RequestBuilder {
+	return &RequestBuilder{request: &api.Request{Resolver: "$webhook", Event: event}}
+}
+
+func (b *RequestBuilder) Arg(name string, value interface{}) *RequestBuilder {
+	raw, err := json.Marshal(value)
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	b.request.Args[name] = raw
+	return b
+}
+
+func (b *RequestBuilder) Args(args map[string]interface{}) *RequestBuilder {
+	for name, value := range args {
+		b.Arg(name, value)
+	}
+	return b
+}
+
+func (b *RequestBuilder) AuthHeader(key string, value string) *RequestBuilder {
+	b.request.AuthHeader = api.AuthHeader{Key: key, Value: value}
+	return b
+}
+
+func (b *RequestBuilder) AccessToken(token string) *RequestBuilder {
+	b.request.AccessToken = token
+	return b
+}
+
+// Request returns the built request. It panics if an argument or parent could not be marshalled.
+func (b *RequestBuilder) Request() *api.Request {
+	if b.err != nil {
+		panic(b.err)
+	}
+	return b.request
+}
+
+var commitTs uint64
+
+func nextCommitTs() uint64 {
+	return atomic.AddUint64(&commitTs, 1)
+}
+
+// AddEvent builds an add event. The inputs are marshalled into the input maps Dgraph sends.
+func AddEvent(typeName string, rootUIDs []string, input ...interface{}) *api.Event {
+	event := &api.Event{TypeName: typeName, Operation: "add", CommitTs: nextCommitTs(), Add: &api.AddEventInfo{RootUIDs: rootUIDs}}
+	for _, i := range input {
+		event.Add.Input = append(event.Add.Input, toMap(i))
+	}
+	return event
+}
+
+// UpdateEvent builds an update event. The patches can be maps or the generated patch types.
+func UpdateEvent(typeName string, rootUIDs []string, setPatch interface{}, removePatch interface{}) *api.Event {
+	return &api.Event{TypeName: typeName, Operation: "update", CommitTs: nextCommitTs(), Update: &api.UpdateEventInfo{
+		RootUIDs:    rootUIDs,
+		SetPatch:    toMap(setPatch),
+		RemovePatch: toMap(removePatch),
+	}}
+}
+
+func DeleteEvent(typeName string, rootUIDs ...string) *api.Event {
+	return &api.Event{TypeName: typeName, Operation: "delete", CommitTs: nextCommitTs(), Delete: &api.DeleteEventInfo{RootUIDs: rootUIDs}}
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return m
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		panic(err)
+	}
+	return m
+}